@@ -30,6 +30,10 @@ type ChartFS struct {
 	localBaseDir    string // base directory path
 }
 
+// PropertiesSchemaFilename the JSON schema describing the product properties,
+// optionally shipped on the product chart root directory.
+const PropertiesSchemaFilename = "properties.schema.json"
+
 // ErrFailedToReadEmbeddedFiles returned when the tarball is not readable.
 var ErrFailedToReadEmbeddedFiles = errors.New("failed to read embedded files")
 
@@ -80,6 +84,26 @@ func (c *ChartFS) ReadFile(name string) ([]byte, error) {
 	return nil, err
 }
 
+// ReadDir reads the directory from the file system, the local file system takes
+// precedence over the embedded.
+func (c *ChartFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	relPath, err := c.relativePath(c.localBaseDir, name)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(c.localFS, relPath)
+	if err == nil {
+		return entries, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if relPath, err = c.relativePath(c.embeddedBaseDir, name); err != nil {
+		return nil, err
+	}
+	return fs.ReadDir(c.embeddedFS, relPath)
+}
+
 // walkChartDir walks through the chart directory, and loads the chart files.
 func (c *ChartFS) walkChartDir(fsys fs.FS, chartPath string) (*chart.Chart, error) {
 	bf := NewBufferedFiles(fsys, chartPath)
@@ -98,6 +122,17 @@ func (c *ChartFS) GetChartFiles(chartPath string) (*chart.Chart, error) {
 	return c.walkChartDir(c.embeddedFS, chartPath)
 }
 
+// GetPropertiesSchema returns the product properties schema shipped by the Helm
+// chart, nil when the chart doesn't carry it.
+func (c *ChartFS) GetPropertiesSchema(hc *chart.Chart) []byte {
+	for _, f := range hc.Files {
+		if f.Name == PropertiesSchemaFilename {
+			return f.Data
+		}
+	}
+	return nil
+}
+
 // walkAndFindChartDirs walks through the filesystem and finds all directories
 // that contain a Helm chart.
 func (c *ChartFS) walkAndFindChartDirs(
@@ -19,6 +19,19 @@ func TestNewChartFS(t *testing.T) {
 		g.Expect(valuesTmplBytes).ToNot(o.BeEmpty())
 	})
 
+	t.Run("ReadDir", func(t *testing.T) {
+		entries, err := c.ReadDir("charts")
+		g.Expect(err).To(o.Succeed())
+		names := []string{}
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		g.Expect(names).To(o.ContainElements("tssc-dh", "values.yaml.tpl"))
+
+		_, err = c.ReadDir("missing")
+		g.Expect(err).NotTo(o.Succeed())
+	})
+
 	t.Run("GetChartForDep", func(t *testing.T) {
 		chart, err := c.GetChartFiles("charts/tssc-openshift")
 		g.Expect(err).To(o.Succeed())
@@ -37,6 +50,17 @@ func TestNewChartFS(t *testing.T) {
 		g.Expect(names).To(o.ContainElement("templates/NOTES.txt"))
 	})
 
+	t.Run("GetPropertiesSchema", func(t *testing.T) {
+		dh, err := c.GetChartFiles("charts/tssc-dh")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(c.GetPropertiesSchema(dh)).
+			To(o.ContainSubstring(`"authProvider"`))
+
+		openShift, err := c.GetChartFiles("charts/tssc-openshift")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(c.GetPropertiesSchema(openShift)).To(o.BeNil())
+	})
+
 	t.Run("GetAllCharts", func(t *testing.T) {
 		charts, err := c.GetAllCharts()
 		g.Expect(err).To(o.Succeed())
@@ -0,0 +1,148 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Outcome represents the result of a chart deployment.
+type Outcome string
+
+const (
+	// InProgress the chart deployment has started.
+	InProgress Outcome = "in-progress"
+	// Succeeded the chart is deployed successfully.
+	Succeeded Outcome = "succeeded"
+	// Failed the chart deployment has failed.
+	Failed Outcome = "failed"
+	// RolledBack the chart is rolled back to the baseline revision.
+	RolledBack Outcome = "rolled-back"
+)
+
+// Entry represents the deployment progress of a single chart.
+type Entry struct {
+	// Chart the Helm chart name.
+	Chart string `yaml:"chart"`
+	// Namespace the release namespace.
+	Namespace string `yaml:"namespace"`
+	// Revision the Helm release revision, when deployed.
+	Revision int `yaml:"revision,omitempty"`
+	// ChartDigest the digest of the Helm chart content.
+	ChartDigest string `yaml:"chartDigest"`
+	// ValuesDigest the digest of the rendered values, when rendered.
+	ValuesDigest string `yaml:"valuesDigest,omitempty"`
+	// Outcome the chart deployment outcome.
+	Outcome Outcome `yaml:"outcome"`
+	// Error the error message, when the deployment failed.
+	Error string `yaml:"error,omitempty"`
+	// UpdatedAt the last time the entry was recorded.
+	UpdatedAt time.Time `yaml:"updatedAt"`
+}
+
+// Checkpoint represents the progress of a deployment, recorded per chart, and
+// the configuration used on it.
+type Checkpoint struct {
+	// ConfigDigest the digest of the installer configuration.
+	ConfigDigest string `yaml:"configDigest"`
+	// Baseline the release revision of each chart before the deployment
+	// started, zero when the release didn't exist.
+	Baseline map[string]int `yaml:"baseline"`
+	// Charts the deployment progress of each chart, in deployment order.
+	Charts []Entry `yaml:"charts"`
+}
+
+// ErrCheckpointMismatch the checkpoint doesn't match the current deployment.
+var ErrCheckpointMismatch = errors.New("checkpoint does not match the deployment")
+
+// Get returns the entry for the chart, nil when not recorded.
+func (c *Checkpoint) Get(name string) *Entry {
+	i := slices.IndexFunc(c.Charts, func(e Entry) bool {
+		return e.Chart == name
+	})
+	if i < 0 {
+		return nil
+	}
+	return &c.Charts[i]
+}
+
+// Record adds or replaces the chart entry, updating its timestamp.
+func (c *Checkpoint) Record(e Entry) {
+	e.UpdatedAt = time.Now().UTC()
+	if existing := c.Get(e.Chart); existing != nil {
+		*existing = e
+		return
+	}
+	c.Charts = append(c.Charts, e)
+}
+
+// Succeeded checks if the chart is deployed successfully.
+func (c *Checkpoint) Succeeded(name string) bool {
+	e := c.Get(name)
+	return e != nil && e.Outcome == Succeeded
+}
+
+// Completed checks if all the informed charts are deployed successfully.
+func (c *Checkpoint) Completed(names []string) bool {
+	for _, name := range names {
+		if !c.Succeeded(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Unfinished returns the charts which deployment has failed, or was interrupted
+// while in progress.
+func (c *Checkpoint) Unfinished() []string {
+	names := []string{}
+	for _, e := range c.Charts {
+		if e.Outcome == Failed || e.Outcome == InProgress {
+			names = append(names, e.Chart)
+		}
+	}
+	return names
+}
+
+// SetBaseline records the release revision of the chart before the deployment,
+// zero when the release doesn't exist.
+func (c *Checkpoint) SetBaseline(name string, revision int) {
+	if c.Baseline == nil {
+		c.Baseline = map[string]int{}
+	}
+	c.Baseline[name] = revision
+}
+
+// Verify asserts the configuration and charts digests haven't changed since the
+// checkpoint was recorded. The chart digests are informed by chart name.
+func (c *Checkpoint) Verify(
+	configDigest string,
+	chartDigests map[string]string,
+) error {
+	if c.ConfigDigest != configDigest {
+		return fmt.Errorf("%w: the configuration has changed",
+			ErrCheckpointMismatch)
+	}
+	for _, e := range c.Charts {
+		digest, exists := chartDigests[e.Chart]
+		if !exists {
+			return fmt.Errorf("%w: chart %q is no longer deployed",
+				ErrCheckpointMismatch, e.Chart)
+		}
+		if e.ChartDigest != digest {
+			return fmt.Errorf("%w: chart %q has changed",
+				ErrCheckpointMismatch, e.Chart)
+		}
+	}
+	return nil
+}
+
+// NewCheckpoint instantiates an empty checkpoint for the configuration digest.
+func NewCheckpoint(configDigest string) *Checkpoint {
+	return &Checkpoint{
+		ConfigDigest: configDigest,
+		Baseline:     map[string]int{},
+		Charts:       []Entry{},
+	}
+}
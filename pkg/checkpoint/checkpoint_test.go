@@ -0,0 +1,75 @@
+package checkpoint
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestCheckpoint(t *testing.T) {
+	g := o.NewWithT(t)
+
+	c := NewCheckpoint("sha256:config")
+	c.Record(Entry{Chart: "tssc-openshift", ChartDigest: "sha256:a", Outcome: InProgress})
+	c.Record(Entry{Chart: "tssc-subscriptions", ChartDigest: "sha256:b", Outcome: Failed})
+	c.Record(Entry{Chart: "tssc-openshift", ChartDigest: "sha256:a", Outcome: Succeeded})
+
+	t.Run("Record", func(t *testing.T) {
+		g.Expect(c.Charts).To(o.HaveLen(2))
+		g.Expect(c.Get("tssc-openshift").Outcome).To(o.Equal(Succeeded))
+		g.Expect(c.Get("tssc-openshift").UpdatedAt.IsZero()).To(o.BeFalse())
+		g.Expect(c.Get("tssc-unknown")).To(o.BeNil())
+	})
+
+	t.Run("Succeeded", func(t *testing.T) {
+		g.Expect(c.Succeeded("tssc-openshift")).To(o.BeTrue())
+		g.Expect(c.Succeeded("tssc-subscriptions")).To(o.BeFalse())
+		g.Expect(c.Succeeded("tssc-unknown")).To(o.BeFalse())
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		g.Expect(c.Completed([]string{"tssc-openshift"})).To(o.BeTrue())
+		g.Expect(c.Completed(
+			[]string{"tssc-openshift", "tssc-subscriptions"})).To(o.BeFalse())
+		g.Expect(c.Completed(
+			[]string{"tssc-openshift", "tssc-unknown"})).To(o.BeFalse())
+	})
+
+	t.Run("Unfinished", func(t *testing.T) {
+		g.Expect(c.Unfinished()).To(o.Equal([]string{"tssc-subscriptions"}))
+
+		c := NewCheckpoint("sha256:config")
+		c.Record(Entry{Chart: "tssc-openshift", Outcome: InProgress})
+		c.Record(Entry{Chart: "tssc-iam", Outcome: RolledBack})
+		g.Expect(c.Unfinished()).To(o.Equal([]string{"tssc-openshift"}))
+	})
+
+	t.Run("SetBaseline", func(t *testing.T) {
+		c.SetBaseline("tssc-openshift", 3)
+		c.SetBaseline("tssc-subscriptions", 0)
+		g.Expect(c.Baseline).To(o.Equal(map[string]int{
+			"tssc-openshift":     3,
+			"tssc-subscriptions": 0,
+		}))
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		digests := map[string]string{
+			"tssc-openshift":     "sha256:a",
+			"tssc-subscriptions": "sha256:b",
+			"tssc-iam":           "sha256:c",
+		}
+		g.Expect(c.Verify("sha256:config", digests)).To(o.Succeed())
+
+		g.Expect(c.Verify("sha256:other", digests)).
+			To(o.MatchError(ErrCheckpointMismatch))
+
+		digests["tssc-subscriptions"] = "sha256:changed"
+		g.Expect(c.Verify("sha256:config", digests)).
+			To(o.MatchError(o.ContainSubstring(`"tssc-subscriptions" has changed`)))
+
+		delete(digests, "tssc-subscriptions")
+		g.Expect(c.Verify("sha256:config", digests)).
+			To(o.MatchError(ErrCheckpointMismatch))
+	})
+}
@@ -0,0 +1,100 @@
+package checkpoint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"slices"
+	"strings"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// digestPrefix identifies the hashing algorithm employed on digests.
+const digestPrefix = "sha256:"
+
+// sum returns the digest of the hash informed.
+func sum(h hash.Hash) string {
+	return digestPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// writeChart writes the Helm chart content on the hash, including metadata,
+// templates, files, default values and its sub-charts. Files are written sorted
+// by name, thus the digest only changes when the chart content changes.
+func writeChart(h hash.Hash, hc *chart.Chart) error {
+	metadata, err := json.Marshal(hc.Metadata)
+	if err != nil {
+		return err
+	}
+	h.Write(metadata)
+	values, err := json.Marshal(hc.Values)
+	if err != nil {
+		return err
+	}
+	h.Write(values)
+	h.Write(hc.Schema)
+
+	files := slices.Concat(hc.Templates, hc.Files)
+	slices.SortFunc(files, func(a, b *chart.File) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, f := range files {
+		h.Write([]byte(f.Name))
+		h.Write(f.Data)
+	}
+
+	dependencies := hc.Dependencies()
+	slices.SortFunc(dependencies, func(a, b *chart.Chart) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	for _, sub := range dependencies {
+		if err = writeChart(h, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChartDigest returns the digest of the Helm chart content.
+func ChartDigest(hc *chart.Chart) (string, error) {
+	h := sha256.New()
+	if err := writeChart(h, hc); err != nil {
+		return "", err
+	}
+	return sum(h), nil
+}
+
+// ValuesDigest returns the digest of the Helm chart values, the values are
+// serialized as JSON, which sorts the keys.
+func ValuesDigest(vals map[string]interface{}) (string, error) {
+	payload, err := json.Marshal(vals)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(payload)
+	return sum(h), nil
+}
+
+// releaseDigestLength the length of the release digest, it must fit on a
+// Kubernetes label value.
+const releaseDigestLength = 40
+
+// ReleaseDigest combines the chart and values digests on a short hexadecimal
+// digest, suitable for labeling the Helm release.
+func ReleaseDigest(chartDigest, valuesDigest string) string {
+	h := sha256.New()
+	h.Write([]byte(chartDigest))
+	h.Write([]byte(valuesDigest))
+	return hex.EncodeToString(h.Sum(nil))[:releaseDigestLength]
+}
+
+// ConfigDigest returns the digest of the installer configuration payload.
+func ConfigDigest(cfg *config.Config) string {
+	h := sha256.New()
+	h.Write([]byte(cfg.String()))
+	return sum(h)
+}
@@ -0,0 +1,59 @@
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestDigest(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cfs, err := chartfs.NewChartFS("../../installer")
+	g.Expect(err).To(o.Succeed())
+
+	t.Run("ChartDigest", func(t *testing.T) {
+		hc, err := cfs.GetChartFiles("charts/tssc-tpa")
+		g.Expect(err).To(o.Succeed())
+
+		digest, err := ChartDigest(hc)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(digest).To(o.HavePrefix(digestPrefix))
+
+		again, err := ChartDigest(hc)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(again).To(o.Equal(digest))
+
+		hc.Templates[0].Data = append(hc.Templates[0].Data, '\n')
+		changed, err := ChartDigest(hc)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(changed).NotTo(o.Equal(digest))
+	})
+
+	t.Run("ValuesDigest", func(t *testing.T) {
+		a, err := ValuesDigest(map[string]interface{}{"a": 1, "b": "c"})
+		g.Expect(err).To(o.Succeed())
+		b, err := ValuesDigest(map[string]interface{}{"b": "c", "a": 1})
+		g.Expect(err).To(o.Succeed())
+		g.Expect(a).To(o.Equal(b))
+	})
+
+	t.Run("ReleaseDigest", func(t *testing.T) {
+		digest := ReleaseDigest("sha256:chart", "sha256:values")
+		g.Expect(digest).To(o.HaveLen(releaseDigestLength))
+		g.Expect(digest).To(o.MatchRegexp(`^[0-9a-f]+$`))
+		g.Expect(ReleaseDigest("sha256:chart", "sha256:values")).
+			To(o.Equal(digest))
+		g.Expect(ReleaseDigest("sha256:chart", "sha256:other")).
+			NotTo(o.Equal(digest))
+	})
+
+	t.Run("ConfigDigest", func(t *testing.T) {
+		cfg, err := config.NewConfigFromFile(cfs, "config.yaml")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(ConfigDigest(cfg)).To(o.HavePrefix(digestPrefix))
+	})
+}
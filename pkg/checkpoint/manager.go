@@ -0,0 +1,118 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Manager the actor responsible for persisting the deployment checkpoint in the
+// cluster, as a ConfigMap next to the installer configuration.
+type Manager struct {
+	kube      *k8s.Kube // kubernetes client
+	namespace string    // installer namespace
+}
+
+const (
+	// Filename the ConfigMap key storing the checkpoint.
+	Filename = "checkpoint.yaml"
+	// Label label to identify the checkpoint ConfigMap.
+	Label = "tssc.redhat-appstudio.github.com/checkpoint"
+	// Name name of the checkpoint ConfigMap.
+	Name = "tssc-deploy-checkpoint"
+)
+
+var (
+	// ErrCheckpointNotFound when the checkpoint isn't recorded in the cluster.
+	ErrCheckpointNotFound = errors.New("deployment checkpoint not found")
+	// ErrIncompleteCheckpoint when the ConfigMap exists, but doesn't contain the
+	// expected payload.
+	ErrIncompleteCheckpoint = errors.New("invalid checkpoint found in the cluster")
+)
+
+// Get retrieves the checkpoint from the cluster.
+func (m *Manager) Get(ctx context.Context) (*Checkpoint, error) {
+	coreClient, err := m.kube.CoreV1ClientSet(m.namespace)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := coreClient.ConfigMaps(m.namespace).
+		Get(ctx, Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: ConfigMap %s/%s",
+				ErrCheckpointNotFound, m.namespace, Name)
+		}
+		return nil, err
+	}
+	payload, ok := cm.Data[Filename]
+	if !ok || len(payload) == 0 {
+		return nil, fmt.Errorf("%w: key %q not found in ConfigMap %s/%s",
+			ErrIncompleteCheckpoint, Filename, m.namespace, Name)
+	}
+	c := &Checkpoint{}
+	if err = yaml.Unmarshal([]byte(payload), c); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrIncompleteCheckpoint, err)
+	}
+	return c, nil
+}
+
+// Save creates or updates the checkpoint ConfigMap in the cluster.
+func (m *Manager) Save(ctx context.Context, c *Checkpoint) error {
+	payload, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	coreClient, err := m.kube.CoreV1ClientSet(m.namespace)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: m.namespace,
+			Labels: map[string]string{
+				Label: "true",
+			},
+		},
+		Data: map[string]string{
+			Filename: string(payload),
+		},
+	}
+	_, err = coreClient.ConfigMaps(m.namespace).
+		Update(ctx, cm, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = coreClient.ConfigMaps(m.namespace).
+			Create(ctx, cm, metav1.CreateOptions{})
+	}
+	return err
+}
+
+// Delete removes the checkpoint ConfigMap from the cluster, if present.
+func (m *Manager) Delete(ctx context.Context) error {
+	coreClient, err := m.kube.CoreV1ClientSet(m.namespace)
+	if err != nil {
+		return err
+	}
+	err = coreClient.ConfigMaps(m.namespace).
+		Delete(ctx, Name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// NewManager instantiates the checkpoint Manager for the installer namespace.
+func NewManager(kube *k8s.Kube, namespace string) *Manager {
+	return &Manager{
+		kube:      kube,
+		namespace: namespace,
+	}
+}
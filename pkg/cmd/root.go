@@ -26,15 +26,19 @@ type RootCmd struct {
 func (r *RootCmd) Cmd() *cobra.Command {
 	logger := r.flags.GetLogger(os.Stdout)
 
-	r.cmd.AddCommand(subcmd.NewIntegration(logger, r.kube))
+	r.cmd.AddCommand(subcmd.NewIntegration(logger, r.flags, r.kube))
 
 	for _, sub := range []subcmd.Interface{
 		subcmd.NewConfig(logger, r.flags, r.cfs, r.kube),
 		subcmd.NewDeploy(logger, r.flags, r.cfs, r.kube),
 		subcmd.NewInstaller(r.flags),
-		subcmd.NewMCPServer(r.flags, r.kube),
+		subcmd.NewMCPServer(r.flags, r.cfs, r.kube),
 		subcmd.NewTemplate(logger, r.flags, r.cfs, r.kube),
 		subcmd.NewTopology(logger, r.cfs, r.kube),
+		subcmd.NewUninstall(logger, r.flags, r.cfs, r.kube),
+		subcmd.NewRollback(logger, r.flags, r.cfs, r.kube),
+		subcmd.NewStatus(logger, r.flags, r.cfs, r.kube),
+		subcmd.NewDiff(logger, r.flags, r.cfs, r.kube),
 	} {
 		r.cmd.AddCommand(subcmd.NewRunner(sub).Cmd())
 	}
@@ -17,6 +17,9 @@ type Products []Product
 
 // Spec contains all configuration sections.
 type Spec struct {
+	// APIVersion the configuration version, older versions are migrated to the
+	// current APIVersion.
+	APIVersion string `yaml:"apiVersion,omitempty"`
 	// Namespace installer's namespace, where the installer's resources will be
 	// deployed. Note, Helm charts deployed by the installer are likely to use a
 	// different namespace.
@@ -29,8 +32,10 @@ type Spec struct {
 
 // Config root configuration structure.
 type Config struct {
-	cfs     *chartfs.ChartFS // embedded filesystem
-	payload []byte           // original configuration payload
+	cfs             *chartfs.ChartFS // embedded filesystem
+	payload         []byte           // original configuration payload
+	resourceVersion string           // ConfigMap resource version, when read
+	migrations      []Migration      // migrations applied in memory, when read
 
 	Installer Spec `yaml:"tssc"` // root configuration for the installer
 }
@@ -107,31 +112,46 @@ func (c *Config) UnmarshalYAML() error {
 	return c.Validate()
 }
 
+// ResourceVersion returns the resource version of the ConfigMap the configuration
+// was read from, empty when not read from the cluster.
+func (c *Config) ResourceVersion() string {
+	return c.resourceVersion
+}
+
+// Migrations returns the migrations applied in memory when the configuration was
+// read from the cluster, not stored yet.
+func (c *Config) Migrations() []Migration {
+	return c.migrations
+}
+
+// SetResourceVersion sets the resource version the configuration is based on, the
+// cluster configuration is only updated when the ConfigMap didn't change since.
+func (c *Config) SetResourceVersion(resourceVersion string) {
+	c.resourceVersion = resourceVersion
+}
+
 // String returns this configuration as string, indented with two spaces.
 func (c *Config) String() string {
 	return string(c.payload)
 }
 
-// NewConfigFromFile returns a new Config instance based on the informed file.
+// NewConfigFromFile returns a new Config instance based on the informed file, the
+// payload is migrated to the current APIVersion, and must comply with the
+// configuration schema.
 func NewConfigFromFile(cfs *chartfs.ChartFS, configPath string) (*Config, error) {
-	c := &Config{cfs: cfs}
-	var err error
-	c.payload, err = c.cfs.ReadFile(configPath)
-	if err != nil {
-		return nil, err
-	}
-	if err = c.UnmarshalYAML(); err != nil {
-		return nil, err
-	}
-	return c, nil
+	return NewConfigFromFiles(cfs, configPath)
 }
 
-// NewConfigFromBytes instantiates a new Config from the bytes payload informed.
+// NewConfigFromBytes instantiates a new Config from the bytes payload informed,
+// the payload must comply with the configuration schema.
 func NewConfigFromBytes(payload []byte) (*Config, error) {
 	c := &Config{payload: payload}
 	if err := yaml.Unmarshal(payload, c); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrUnmarshalConfig, err)
 	}
+	if err := validateSchema(payload); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
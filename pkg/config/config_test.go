@@ -6,6 +6,8 @@ import (
 	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 
 	o "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewConfigFromFile(t *testing.T) {
@@ -49,6 +51,22 @@ func TestNewConfigFromFile(t *testing.T) {
 		g.Expect(err).To(o.Succeed())
 	})
 
+	t.Run("ResourceVersion", func(t *testing.T) {
+		g.Expect(cfg.ResourceVersion()).To(o.BeEmpty())
+		cfg.SetResourceVersion("42")
+		g.Expect(cfg.ResourceVersion()).To(o.Equal("42"))
+
+		// Editing keeps the resource version the changes are based on.
+		g.Expect(cfg.Set("settings.crc", "true")).To(o.Succeed())
+		g.Expect(cfg.ResourceVersion()).To(o.Equal("42"))
+
+		err := conflictErr(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tssc", Name: Name},
+		}, "42")
+		g.Expect(err).To(o.MatchError(ErrConfigConflict))
+		g.Expect(err).To(o.MatchError(o.ContainSubstring("tssc/tssc-config")))
+	})
+
 	t.Run("String", func(t *testing.T) {
 		payload := cfg.String()
 		g.Expect(string(payload)).To(o.ContainSubstring("tssc:"))
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/constants"
+
+	"github.com/pmezard/go-difflib/difflib"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HistoryLabel label to identify the configuration revision ConfigMaps.
+	HistoryLabel = "tssc.redhat-appstudio.github.com/config-history"
+	// HistoryLimit the amount of previous revisions kept in the cluster.
+	HistoryLimit = 20
+
+	// RevisionAnnotation the configuration revision number.
+	RevisionAnnotation = "tssc.redhat-appstudio.github.com/revision"
+	// AuthorAnnotation the user who applied the configuration revision.
+	AuthorAnnotation = "tssc.redhat-appstudio.github.com/author"
+	// TimestampAnnotation when the configuration revision was applied.
+	TimestampAnnotation = "tssc.redhat-appstudio.github.com/timestamp"
+	// VersionAnnotation the CLI version which applied the configuration revision.
+	VersionAnnotation = "tssc.redhat-appstudio.github.com/cli-version"
+)
+
+// ErrRevisionNotFound the configuration revision isn't recorded in the cluster.
+var ErrRevisionNotFound = errors.New("configuration revision not found")
+
+// Revision a configuration revision, either the current configuration or a
+// previous one recorded on the history.
+type Revision struct {
+	// Number the revision number, incremented on every update.
+	Number int
+	// Author the user who applied the revision.
+	Author string
+	// Timestamp when the revision was applied.
+	Timestamp time.Time
+	// CLIVersion the CLI version which applied the revision.
+	CLIVersion string
+	// Current the revision is the current cluster configuration.
+	Current bool
+	// Payload the configuration payload.
+	Payload string
+	// ResourceVersion the resource version of the ConfigMap storing the revision.
+	ResourceVersion string
+}
+
+// historyName returns the name of the ConfigMap storing the revision.
+func historyName(revision int) string {
+	return fmt.Sprintf("%s-revision-%d", Name, revision)
+}
+
+// revisionNumber returns the revision number annotated on the ConfigMap, the
+// configurations applied before the history was introduced are the first.
+func revisionNumber(cm *corev1.ConfigMap) int {
+	revision, err := strconv.Atoi(cm.GetAnnotations()[RevisionAnnotation])
+	if err != nil || revision < 1 {
+		return 1
+	}
+	return revision
+}
+
+// revisionFromConfigMap returns the revision stored on the ConfigMap.
+func revisionFromConfigMap(cm *corev1.ConfigMap, current bool) Revision {
+	annotations := cm.GetAnnotations()
+	r := Revision{
+		Number:     revisionNumber(cm),
+		Author:     annotations[AuthorAnnotation],
+		CLIVersion: annotations[VersionAnnotation],
+		Current:    current,
+		Payload:    cm.Data[Filename],
+
+		ResourceVersion: cm.GetResourceVersion(),
+	}
+	ts, err := time.Parse(time.RFC3339, annotations[TimestampAnnotation])
+	if err != nil {
+		ts = cm.GetCreationTimestamp().Time
+	}
+	r.Timestamp = ts
+	return r
+}
+
+// whoami returns the user name authenticated on the cluster, "unknown" when the
+// cluster doesn't support reviewing the user identity.
+func (m *ConfigMapManager) whoami(ctx context.Context) string {
+	cs, err := m.kube.ClientSet("")
+	if err != nil {
+		return "unknown"
+	}
+	review, err := cs.AuthenticationV1().SelfSubjectReviews().Create(
+		ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil || review.Status.UserInfo.Username == "" {
+		return "unknown"
+	}
+	return review.Status.UserInfo.Username
+}
+
+// annotate annotates the ConfigMap with the revision number, author, timestamp
+// and CLI version.
+func (m *ConfigMapManager) annotate(
+	ctx context.Context,
+	cm *corev1.ConfigMap,
+	revision int,
+) {
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[RevisionAnnotation] = strconv.Itoa(revision)
+	cm.Annotations[AuthorAnnotation] = m.whoami(ctx)
+	cm.Annotations[TimestampAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	cm.Annotations[VersionAnnotation] = constants.Version
+}
+
+// archive stores the current configuration ConfigMap as a history revision, and
+// prunes the revisions beyond the history limit.
+func (m *ConfigMapManager) archive(
+	ctx context.Context,
+	current *corev1.ConfigMap,
+) error {
+	coreClient, err := m.kube.CoreV1ClientSet(current.GetNamespace())
+	if err != nil {
+		return err
+	}
+	revision := revisionNumber(current)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        historyName(revision),
+			Namespace:   current.GetNamespace(),
+			Labels:      map[string]string{HistoryLabel: "true"},
+			Annotations: maps.Clone(current.GetAnnotations()),
+		},
+		Data: map[string]string{Filename: current.Data[Filename]},
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[RevisionAnnotation] = strconv.Itoa(revision)
+	configMaps := coreClient.ConfigMaps(cm.GetNamespace())
+	if _, err = configMaps.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to record configuration revision %d: %w",
+			revision, err)
+	}
+
+	// Pruning the revisions beyond the history limit, oldest first.
+	history, err := m.listHistory(ctx, current.GetNamespace())
+	if err != nil {
+		return err
+	}
+	for len(history) > HistoryLimit {
+		if err = configMaps.Delete(
+			ctx, historyName(history[0].Number), metav1.DeleteOptions{},
+		); err != nil {
+			return err
+		}
+		history = history[1:]
+	}
+	return nil
+}
+
+// listHistory lists the previous configuration revisions on the namespace,
+// sorted by revision number.
+func (m *ConfigMapManager) listHistory(
+	ctx context.Context,
+	namespace string,
+) ([]Revision, error) {
+	coreClient, err := m.kube.CoreV1ClientSet(namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := coreClient.ConfigMaps(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true", HistoryLabel),
+	})
+	if err != nil {
+		return nil, err
+	}
+	revisions := []Revision{}
+	for i := range list.Items {
+		revisions = append(revisions, revisionFromConfigMap(&list.Items[i], false))
+	}
+	slices.SortFunc(revisions, func(a, b Revision) int {
+		return a.Number - b.Number
+	})
+	return revisions, nil
+}
+
+// History returns the configuration revisions recorded in the cluster, sorted by
+// revision number, the last is the current configuration.
+func (m *ConfigMapManager) History(ctx context.Context) ([]Revision, error) {
+	current, err := m.GetConfigMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := m.listHistory(ctx, current.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return append(revisions, revisionFromConfigMap(current, true)), nil
+}
+
+// GetRevision returns the configuration revision by number.
+func (m *ConfigMapManager) GetRevision(
+	ctx context.Context,
+	number int,
+) (*Revision, error) {
+	revisions, err := m.History(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range revisions {
+		if revisions[i].Number == number {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: revision %d", ErrRevisionNotFound, number)
+}
+
+// Diff returns the unified diff between the configuration payloads, empty when
+// they are the same.
+func Diff(fromFile, from, toFile, to string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// DiffRevisions returns the unified diff between the configuration revisions,
+// empty when the payloads are the same.
+func DiffRevisions(from, to *Revision) (string, error) {
+	return Diff(
+		fmt.Sprintf("revision/%d", from.Number), from.Payload,
+		fmt.Sprintf("revision/%d", to.Number), to.Payload,
+	)
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRevision(t *testing.T) {
+	g := o.NewWithT(t)
+
+	created := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	t.Run("Legacy", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+			Data:       map[string]string{Filename: "tssc: {}\n"},
+		}
+		r := revisionFromConfigMap(cm, true)
+		g.Expect(r.Number).To(o.Equal(1))
+		g.Expect(r.Current).To(o.BeTrue())
+		g.Expect(r.Timestamp).To(o.Equal(created.Time))
+		g.Expect(r.Payload).To(o.Equal("tssc: {}\n"))
+	})
+
+	t.Run("Annotated", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              historyName(3),
+				CreationTimestamp: created,
+				Annotations: map[string]string{
+					RevisionAnnotation:  "3",
+					AuthorAnnotation:    "kube:admin",
+					TimestampAnnotation: "2025-02-03T04:05:06Z",
+					VersionAnnotation:   "v1.7.0",
+				},
+			},
+		}
+		r := revisionFromConfigMap(cm, false)
+		g.Expect(cm.GetName()).To(o.Equal("tssc-config-revision-3"))
+		g.Expect(r.Number).To(o.Equal(3))
+		g.Expect(r.Author).To(o.Equal("kube:admin"))
+		g.Expect(r.CLIVersion).To(o.Equal("v1.7.0"))
+		g.Expect(r.Timestamp).
+			To(o.Equal(time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)))
+	})
+
+	t.Run("DiffRevisions", func(t *testing.T) {
+		from := &Revision{Number: 1, Payload: "a: 1\nb: 2\n"}
+		to := &Revision{Number: 2, Payload: "a: 1\nb: 3\n"}
+
+		diff, err := DiffRevisions(from, to)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(diff).To(o.ContainSubstring("--- revision/1"))
+		g.Expect(diff).To(o.ContainSubstring("+++ revision/2"))
+		g.Expect(diff).To(o.ContainSubstring("-b: 2\n+b: 3\n"))
+
+		diff, err = DiffRevisions(from, from)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(diff).To(o.BeEmpty())
+	})
+}
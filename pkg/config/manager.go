@@ -8,13 +8,14 @@ import (
 	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ConfigMapManager the actor responsible for managing installer configuration in
 // the cluster.
 type ConfigMapManager struct {
-	kube *k8s.Kube // kubernetes client
+	kube k8s.Interface // kubernetes client
 }
 
 const (
@@ -34,6 +35,12 @@ var (
 	// ErrIncompleteConfigMap when the ConfigMap exists, but doesn't contain the
 	// expected payload.
 	ErrIncompleteConfigMap = errors.New("invalid configmap found in the cluster")
+	// ErrConfigConflict when the ConfigMap changed since the configuration was
+	// read from the cluster.
+	ErrConfigConflict = errors.New("cluster configuration changed concurrently")
+	// ErrConfigNotVersioned when the configuration doesn't carry the ConfigMap
+	// resource version, concurrent changes can't be detected.
+	ErrConfigNotVersioned = errors.New("configuration resource version not set")
 )
 
 // selectorLabel returns the label selector.
@@ -87,7 +94,8 @@ func (m *ConfigMapManager) GetConfigMap(
 	return &configMapList.Items[0], nil
 }
 
-// GetConfig retrieves configuration from a cluster's ConfigMap.
+// GetConfig retrieves configuration from a cluster's ConfigMap, migrated to the
+// current APIVersion. The migrations applied are informed by Migrations.
 func (m *ConfigMapManager) GetConfig(ctx context.Context) (*Config, error) {
 	configMap, err := m.GetConfigMap(ctx)
 	if err != nil {
@@ -104,7 +112,19 @@ func (m *ConfigMapManager) GetConfig(ctx context.Context) (*Config, error) {
 		)
 	}
 
-	return NewConfigFromBytes([]byte(payload))
+	// Configurations stored by previous installer versions are migrated in memory,
+	// until explicitly stored.
+	migrated, applied, err := Migrate([]byte(payload))
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := NewConfigFromBytes(migrated)
+	if err != nil {
+		return nil, err
+	}
+	cfg.resourceVersion = configMap.GetResourceVersion()
+	cfg.migrations = applied
+	return cfg, nil
 }
 
 // configMapForConfig generate a ConfigMap resource based on informed Config.
@@ -125,15 +145,27 @@ func (m *ConfigMapManager) configMapForConfig(
 	}, nil
 }
 
-// Create Bootstrap a ConfigMap with the provided configuration.
+// Create Bootstrap a ConfigMap with the provided configuration, annotated as the
+// next configuration revision.
 func (m *ConfigMapManager) Create(ctx context.Context, cfg *Config) error {
 	cm, err := m.configMapForConfig(cfg)
 	if err != nil {
 		return err
 	}
+	// The revision numbers carry on after the revisions recorded, when the
+	// configuration was deleted and created again.
+	history, err := m.listHistory(ctx, cfg.Installer.Namespace)
+	if err != nil {
+		return err
+	}
+	revision := 1
+	if len(history) > 0 {
+		revision = history[len(history)-1].Number + 1
+	}
+	m.annotate(ctx, cm, revision)
 	coreClient, err := m.kube.CoreV1ClientSet(cfg.Installer.Namespace)
 	if err != nil {
-		return nil
+		return err
 	}
 	_, err = coreClient.
 		ConfigMaps(cfg.Installer.Namespace).
@@ -141,20 +173,90 @@ func (m *ConfigMapManager) Create(ctx context.Context, cfg *Config) error {
 	return err
 }
 
-// Update updates a ConfigMap with informed configuration.
+// conflictErr returns the conflict error for the ConfigMap.
+func conflictErr(cm *corev1.ConfigMap, resourceVersion string) error {
+	return fmt.Errorf(
+		"%w: ConfigMap %s/%s changed since it was read (resourceVersion %q), "+
+			"read the configuration again and re-apply the changes",
+		ErrConfigConflict, cm.GetNamespace(), cm.GetName(), resourceVersion)
+}
+
+// Update updates a ConfigMap with informed configuration, once updated the
+// previous configuration is recorded as a history revision. The configuration
+// must carry the resource version it's based on, see SetResourceVersion, the
+// update only takes place if the ConfigMap didn't change since, otherwise
+// ErrConfigConflict is returned.
 func (m *ConfigMapManager) Update(ctx context.Context, cfg *Config) error {
+	resourceVersion := cfg.resourceVersion
+	if resourceVersion == "" {
+		return fmt.Errorf(
+			"%w: read the configuration from the cluster before updating it",
+			ErrConfigNotVersioned)
+	}
 	cm, err := m.configMapForConfig(cfg)
 	if err != nil {
 		return err
 	}
 	coreClient, err := m.kube.CoreV1ClientSet(cfg.Installer.Namespace)
 	if err != nil {
-		return nil
+		return err
 	}
-	_, err = coreClient.
+	current, err := coreClient.ConfigMaps(cfg.Installer.Namespace).
+		Get(ctx, Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if resourceVersion != current.GetResourceVersion() {
+		return conflictErr(current, resourceVersion)
+	}
+	m.annotate(ctx, cm, revisionNumber(current)+1)
+	cm.SetResourceVersion(resourceVersion)
+	updated, err := coreClient.
 		ConfigMaps(cfg.Installer.Namespace).
 		Update(ctx, cm, metav1.UpdateOptions{})
-	return err
+	if apierrors.IsConflict(err) {
+		return conflictErr(current, resourceVersion)
+	}
+	if err != nil {
+		return err
+	}
+	cfg.resourceVersion = updated.GetResourceVersion()
+	// Only the writer whose update succeeded records the previous revision.
+	if err = m.archive(ctx, current); err != nil {
+		return fmt.Errorf("configuration updated, but %w", err)
+	}
+	return nil
+}
+
+// Modify reads the cluster configuration, modifies it with the informed function,
+// and updates it. The configuration informed is used on the first attempt, when
+// nil it's read from the cluster. On ErrConfigConflict the configuration is read
+// again and the function re-applied, up to the informed number of retries.
+func (m *ConfigMapManager) Modify(
+	ctx context.Context,
+	cfg *Config,
+	retries int,
+	fn func(*Config) error,
+) (*Config, error) {
+	for attempt := 0; ; attempt++ {
+		var err error
+		if cfg == nil {
+			if cfg, err = m.GetConfig(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if err = fn(cfg); err != nil {
+			return nil, err
+		}
+		err = m.Update(ctx, cfg)
+		if err == nil {
+			return cfg, nil
+		}
+		if !errors.Is(err, ErrConfigConflict) || attempt >= retries {
+			return nil, err
+		}
+		cfg = nil
+	}
 }
 
 // Delete find and delete the ConfigMap from the cluster.
@@ -166,7 +268,7 @@ func (m *ConfigMapManager) Delete(ctx context.Context) error {
 
 	coreClient, err := m.kube.CoreV1ClientSet(cm.GetNamespace())
 	if err != nil {
-		return nil
+		return err
 	}
 
 	return coreClient.ConfigMaps(cm.GetNamespace()).
@@ -174,7 +276,7 @@ func (m *ConfigMapManager) Delete(ctx context.Context) error {
 }
 
 // NewConfigMapManager instantiates the ConfigMapManager.
-func NewConfigMapManager(kube *k8s.Kube) *ConfigMapManager {
+func NewConfigMapManager(kube k8s.Interface) *ConfigMapManager {
 	return &ConfigMapManager{
 		kube: kube,
 	}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+
+	o "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newVersionedFakeKube returns a fake client which assigns and checks the
+// ConfigMaps resource version, like the API server does.
+func newVersionedFakeKube() *k8s.FakeKube {
+	kube := k8s.NewFakeKube()
+	cs := kube.Fake()
+	version := 0
+	nextVersion := func(action k8stesting.Action) *corev1.ConfigMap {
+		obj := action.(interface{ GetObject() runtime.Object }).GetObject()
+		cm := obj.(*corev1.ConfigMap)
+		version++
+		cm.SetResourceVersion(strconv.Itoa(version))
+		return cm
+	}
+	cs.PrependReactor("create", "configmaps",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			nextVersion(action)
+			return false, nil, nil
+		})
+	cs.PrependReactor("update", "configmaps",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			update := action.(k8stesting.UpdateAction)
+			cm := update.GetObject().(*corev1.ConfigMap)
+			existing, err := cs.Tracker().Get(
+				action.GetResource(), action.GetNamespace(), cm.GetName())
+			if err != nil {
+				return false, nil, nil
+			}
+			current := existing.(*corev1.ConfigMap).GetResourceVersion()
+			if cm.GetResourceVersion() != current {
+				return true, nil, apierrors.NewConflict(
+					action.GetResource().GroupResource(), cm.GetName(), nil)
+			}
+			nextVersion(action)
+			return false, nil, nil
+		})
+	return kube
+}
+
+func TestConfigMapManagerConcurrentWriters(t *testing.T) {
+	g := o.NewWithT(t)
+	ctx := context.Background()
+
+	cfs, err := chartfs.NewChartFS("../../installer")
+	g.Expect(err).To(o.Succeed())
+	cfg, err := NewConfigFromFile(cfs, "config.yaml")
+	g.Expect(err).To(o.Succeed())
+
+	m := NewConfigMapManager(newVersionedFakeKube())
+	g.Expect(m.Create(ctx, cfg)).To(o.Succeed())
+
+	// Both writers read the same configuration revision.
+	a, err := m.GetConfig(ctx)
+	g.Expect(err).To(o.Succeed())
+	b, err := m.GetConfig(ctx)
+	g.Expect(err).To(o.Succeed())
+	g.Expect(a.ResourceVersion()).To(o.Equal(b.ResourceVersion()))
+
+	t.Run("Update", func(t *testing.T) {
+		g.Expect(a.Set("settings.crc", "true")).To(o.Succeed())
+		g.Expect(m.Update(ctx, a)).To(o.Succeed())
+
+		g.Expect(b.Set("settings.ci.debug", "true")).To(o.Succeed())
+		g.Expect(m.Update(ctx, b)).To(o.MatchError(ErrConfigConflict))
+
+		// Only the successful update recorded the previous revision.
+		history, err := m.History(ctx)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(history).To(o.HaveLen(2))
+		g.Expect(history[0].Number).To(o.Equal(1))
+		g.Expect(history[1].Number).To(o.Equal(2))
+		g.Expect(history[1].Payload).To(o.Equal(a.String()))
+	})
+
+	t.Run("NotVersioned", func(t *testing.T) {
+		// The configuration loaded from files doesn't carry the resource version.
+		g.Expect(m.Update(ctx, cfg)).To(o.MatchError(ErrConfigNotVersioned))
+
+		current, err := m.GetConfig(ctx)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(current.String()).To(o.Equal(a.String()))
+	})
+
+	t.Run("Modify", func(t *testing.T) {
+		attempts := 0
+		debug := func(c *Config) error {
+			attempts++
+			return c.Set("settings.ci.debug", "true")
+		}
+
+		_, err := m.Modify(ctx, b, 0, debug)
+		g.Expect(err).To(o.MatchError(ErrConfigConflict))
+		g.Expect(attempts).To(o.Equal(1))
+
+		attempts = 0
+		modified, err := m.Modify(ctx, b, 1, debug)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(attempts).To(o.Equal(2))
+
+		// The concurrent change is preserved, the modification re-applied on it.
+		current, err := m.GetConfig(ctx)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(current.ResourceVersion()).
+			To(o.Equal(modified.ResourceVersion()))
+		g.Expect(current.Installer.Settings).To(o.HaveKeyWithValue("crc", true))
+		g.Expect(current.Installer.Settings["ci"]).
+			To(o.HaveKeyWithValue("debug", true))
+
+		history, err := m.History(ctx)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(history).To(o.HaveLen(3))
+		g.Expect(history[2].Number).To(o.Equal(3))
+	})
+}
+
+func TestConfigMapManagerGetConfig(t *testing.T) {
+	g := o.NewWithT(t)
+
+	m := NewConfigMapManager(k8s.NewFakeKube(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: "tssc",
+			Labels:    map[string]string{Label: "true"},
+		},
+		Data: map[string]string{
+			Filename: "tssc:\n  namespace: tssc\n  settings: {}\n",
+		},
+	}))
+
+	// The unversioned configuration is migrated in memory, the migrations
+	// applied are informed to the caller.
+	cfg, err := m.GetConfig(context.Background())
+	g.Expect(err).To(o.Succeed())
+	g.Expect(cfg.Installer.APIVersion).To(o.Equal(APIVersion))
+	g.Expect(cfg.Migrations()).To(o.HaveLen(1))
+	g.Expect(cfg.Migrations()[0].To).To(o.Equal(APIVersion))
+}
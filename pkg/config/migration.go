@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// APIVersion the configuration version supported by the installer, the same as
+// the configuration schema version.
+const APIVersion = SchemaVersion
+
+// ErrUnsupportedAPIVersion the configuration version is unknown to the installer,
+// likely created by a newer installer version.
+var ErrUnsupportedAPIVersion = errors.New("unsupported configuration apiVersion")
+
+// Migration a step upgrading the configuration from a version to the next.
+type Migration struct {
+	// From the configuration version migrated, empty for configurations created
+	// before the versioning was introduced.
+	From string
+	// To the configuration version after the migration.
+	To string
+	// Description describes the changes applied by the migration.
+	Description string
+	// Migrate modifies the "tssc" object, the version is recorded afterwards.
+	Migrate func(spec *yaml.Node) error
+}
+
+// migrations the registry of migration steps, each version is migrated by the
+// step informing it as "From", until the current APIVersion is reached.
+var migrations = []Migration{{
+	From:        "",
+	To:          "v1",
+	Description: "records the configuration apiVersion",
+}}
+
+// setAPIVersion records the version on the "tssc" object, as its first attribute
+// when not present.
+func setAPIVersion(spec *yaml.Node, version string) {
+	if existing := mappingValue(spec, "apiVersion"); existing != nil {
+		existing.Kind = yaml.ScalarNode
+		existing.Tag = "!!str"
+		existing.Value = version
+		return
+	}
+	spec.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "apiVersion"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: version},
+	}, spec.Content...)
+}
+
+// Migrate upgrades the configuration payload to the current APIVersion, applying
+// the migration steps in sequence. Returns the migrated payload and the steps
+// applied, the payload is returned as is when already up to date. Comments on
+// the payload are preserved.
+func Migrate(payload []byte) ([]byte, []Migration, error) {
+	c := &Config{payload: payload}
+	applied := []Migration{}
+	err := c.updatePayload(func(root *yaml.Node) error {
+		spec := mappingValue(root, "tssc")
+		if spec == nil || spec.Kind != yaml.MappingNode {
+			return fmt.Errorf("%w: missing root object", ErrInvalidConfig)
+		}
+		version := ""
+		if v := mappingValue(spec, "apiVersion"); v != nil {
+			version = v.Value
+		}
+		for version != APIVersion {
+			i := slices.IndexFunc(migrations, func(m Migration) bool {
+				return m.From == version
+			})
+			if i < 0 {
+				return fmt.Errorf("%w: %q, the installer supports %q",
+					ErrUnsupportedAPIVersion, version, APIVersion)
+			}
+			m := migrations[i]
+			if m.Migrate != nil {
+				if err := m.Migrate(spec); err != nil {
+					return fmt.Errorf("migrating from %q to %q: %w",
+						m.From, m.To, err)
+				}
+			}
+			setAPIVersion(spec, m.To)
+			applied = append(applied, m)
+			version = m.To
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(applied) == 0 {
+		return payload, applied, nil
+	}
+	return c.payload, applied, nil
+}
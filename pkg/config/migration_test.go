@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestMigrate(t *testing.T) {
+	g := o.NewWithT(t)
+
+	t.Run("Unversioned", func(t *testing.T) {
+		migrated, applied, err := Migrate([]byte(`---
+tssc:
+  # Main installer namespace.
+  namespace: tssc
+  settings: {}
+`))
+		g.Expect(err).To(o.Succeed())
+		g.Expect(applied).To(o.HaveLen(1))
+		g.Expect(applied[0].From).To(o.BeEmpty())
+		g.Expect(applied[0].To).To(o.Equal(APIVersion))
+		g.Expect(string(migrated)).To(o.Equal(`---
+tssc:
+  apiVersion: v1
+  # Main installer namespace.
+  namespace: tssc
+  settings: {}
+`))
+
+		cfg, err := NewConfigFromBytes(migrated)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(cfg.Installer.APIVersion).To(o.Equal(APIVersion))
+	})
+
+	t.Run("Current", func(t *testing.T) {
+		payload := []byte("tssc:\n    apiVersion: v1\n")
+		migrated, applied, err := Migrate(payload)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(applied).To(o.BeEmpty())
+		g.Expect(migrated).To(o.Equal(payload))
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, _, err := Migrate([]byte("tssc:\n  apiVersion: v99\n"))
+		g.Expect(err).To(o.MatchError(ErrUnsupportedAPIVersion))
+
+		_, _, err = Migrate([]byte("other: {}\n"))
+		g.Expect(err).To(o.MatchError(ErrInvalidConfig))
+	})
+
+	t.Run("Registry", func(t *testing.T) {
+		// Every version reaches the current APIVersion, one step at a time.
+		for _, m := range migrations {
+			version, steps := m.From, 0
+			for version != APIVersion && steps <= len(migrations) {
+				for _, next := range migrations {
+					if next.From == version {
+						version = next.To
+						break
+					}
+				}
+				steps++
+			}
+			g.Expect(version).To(o.Equal(APIVersion), m.From)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// resolveAlias returns the node an alias refers to, as a copy without anchor, so
+// it can be placed on another document.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	if node.Kind != yaml.AliasNode || node.Alias == nil {
+		return node
+	}
+	resolved := *node.Alias
+	resolved.Anchor = ""
+	return &resolved
+}
+
+// namedItems checks if all the sequence items are mappings carrying a "name".
+func namedItems(seq *yaml.Node) bool {
+	for _, item := range seq.Content {
+		if name := mappingValue(item, "name"); name == nil ||
+			name.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeNodes deep-merges the overlay node into the base node. Mappings are merged
+// by key, and lists of named objects, like "products", are merged by "name". Any
+// other overlay value replaces the base value, keeping the base comments and
+// anchor when the overlay doesn't carry its own.
+func mergeNodes(base, overlay *yaml.Node) {
+	overlay = resolveAlias(overlay)
+	switch {
+	case base.Kind == yaml.MappingNode && overlay.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(overlay.Content); i += 2 {
+			key, value := overlay.Content[i], overlay.Content[i+1]
+			if existing := mappingValue(base, key.Value); existing != nil {
+				mergeNodes(existing, value)
+				continue
+			}
+			base.Content = append(base.Content, key, resolveAlias(value))
+		}
+	case base.Kind == yaml.SequenceNode && overlay.Kind == yaml.SequenceNode &&
+		namedItems(base) && namedItems(overlay):
+		for _, item := range overlay.Content {
+			name := mappingValue(item, "name").Value
+			if existing := itemByName(base, name); existing != nil {
+				mergeNodes(existing, item)
+				continue
+			}
+			base.Content = append(base.Content, item)
+		}
+	default:
+		replaced := *overlay
+		if replaced.HeadComment == "" && replaced.LineComment == "" {
+			replaced.HeadComment = base.HeadComment
+			replaced.LineComment = base.LineComment
+		}
+		replaced.Anchor = base.Anchor
+		*base = replaced
+	}
+}
+
+// mergeOverlay deep-merges the overlay payload into the configuration document.
+func mergeOverlay(root *yaml.Node, payload []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(payload, &doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrUnmarshalConfig, err)
+	}
+	// Empty overlay, nothing to merge.
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: the overlay must be an object", ErrInvalidConfig)
+	}
+	mergeNodes(root, doc.Content[0])
+	return nil
+}
+
+// NewConfigFromFiles returns a new Config instance merging the informed files in
+// order, the first file is the base configuration and the following files are
+// overlays deep-merged on it. The products are merged by name, so an overlay can
+// toggle a product or change a single property. The merged payload is migrated
+// to the current APIVersion, and must comply with the configuration schema.
+func NewConfigFromFiles(cfs *chartfs.ChartFS, paths ...string) (*Config, error) {
+	if len(paths) == 0 {
+		return nil, ErrEmptyConfig
+	}
+	c := &Config{cfs: cfs}
+	var err error
+	if c.payload, err = cfs.ReadFile(paths[0]); err != nil {
+		return nil, err
+	}
+	for _, path := range paths[1:] {
+		overlay, err := cfs.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.updatePayload(func(root *yaml.Node) error {
+			return mergeOverlay(root, overlay)
+		}); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	// Files created by previous installer versions are migrated.
+	if c.payload, _, err = Migrate(c.payload); err != nil {
+		return nil, err
+	}
+	if err = c.UnmarshalYAML(); err != nil {
+		return nil, err
+	}
+	if err = validateSchema(c.payload); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+
+	o "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMergeOverlay(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cfs, err := chartfs.NewChartFS("../../installer")
+	g.Expect(err).To(o.Succeed())
+
+	t.Run("NewConfigFromFiles", func(t *testing.T) {
+		cfg, err := NewConfigFromFiles(cfs, "config.yaml", "config.yaml")
+		g.Expect(err).To(o.Succeed())
+		base, err := NewConfigFromFile(cfs, "config.yaml")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(cfg.Installer).To(o.Equal(base.Installer))
+
+		_, err = NewConfigFromFiles(cfs)
+		g.Expect(err).To(o.MatchError(ErrEmptyConfig))
+	})
+
+	t.Run("Products", func(t *testing.T) {
+		cfg, err := NewConfigFromFile(cfs, "config.yaml")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(cfg.updatePayload(func(root *yaml.Node) error {
+			return mergeOverlay(root, []byte(`---
+tssc:
+  settings:
+    crc: true
+  products:
+    - name: Trusted Profile Analyzer
+      enabled: false
+    - name: Developer Hub
+      properties:
+        namespacePrefixes: [tssc-app]
+    - name: Custom
+      enabled: false
+`))
+		})).To(o.Succeed())
+
+		merged, err := NewConfigFromBytes([]byte(cfg.String()))
+		g.Expect(err).To(o.Succeed())
+		g.Expect(merged.Installer.Namespace).To(o.Equal("tssc"))
+		g.Expect(merged.Installer.Settings).To(o.HaveKeyWithValue("crc", true))
+		g.Expect(merged.Installer.Settings["ci"]).
+			To(o.HaveKeyWithValue("debug", false))
+		g.Expect(merged.Installer.Products).To(o.HaveLen(7))
+
+		tpa, err := merged.GetProduct("Trusted Profile Analyzer")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(tpa.Enabled).To(o.BeFalse())
+		g.Expect(tpa.GetNamespace()).To(o.Equal("tssc-tpa"))
+
+		dh, err := merged.GetProduct(DeveloperHub)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(dh.Properties).To(o.HaveKeyWithValue("authProvider", "github"))
+		g.Expect(dh.Properties).To(o.HaveKeyWithValue(
+			"namespacePrefixes", []interface{}{"tssc-app"}))
+
+		g.Expect(cfg.String()).To(o.ContainSubstring("# Main installer namespace."))
+		g.Expect(cfg.String()).To(o.ContainSubstring("*installerNamespace"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		cfg, err := NewConfigFromFile(cfs, "config.yaml")
+		g.Expect(err).To(o.Succeed())
+		err = cfg.updatePayload(func(root *yaml.Node) error {
+			return mergeOverlay(root, []byte("- name: Developer Hub\n"))
+		})
+		g.Expect(err).To(o.MatchError(ErrInvalidConfig))
+	})
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidPath the configuration attribute path is invalid, or it can't be
+// found on the configuration.
+var ErrInvalidPath = errors.New("invalid configuration path")
+
+// pathSegment a single segment of the attribute path, a mapping key optionally
+// followed by a sequence item selector, e.g. "products[Developer Hub]".
+type pathSegment struct {
+	key      string // mapping key
+	selector string // sequence item selector, by index or by "name"
+	indexed  bool   // the segment carries a sequence item selector
+}
+
+// String returns the segment as informed on the path.
+func (s pathSegment) String() string {
+	if !s.indexed {
+		return s.key
+	}
+	return fmt.Sprintf("%s[%s]", s.key, s.selector)
+}
+
+// parsePath parses the attribute path, relative to the "tssc" root object. For
+// instance: "settings.ci.debug" or "products[Developer Hub].enabled". The items
+// of a sequence are selected by index or by "name".
+func parsePath(path string) ([]pathSegment, error) {
+	segments := []pathSegment{}
+	var current strings.Builder
+	brackets := false
+	flush := func() {
+		segments = append(segments, pathSegment{key: current.String()})
+		current.Reset()
+	}
+	for _, r := range path {
+		switch {
+		case brackets && r == ']':
+			brackets = false
+			segments[len(segments)-1].selector = current.String()
+			current.Reset()
+		case brackets:
+			current.WriteRune(r)
+		case r == '[':
+			flush()
+			segments[len(segments)-1].indexed = true
+			brackets = true
+		case r == '.':
+			if current.Len() > 0 || len(segments) == 0 ||
+				!segments[len(segments)-1].indexed {
+				flush()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if brackets {
+		return nil, fmt.Errorf("%w: %q: unterminated selector", ErrInvalidPath, path)
+	}
+	if current.Len() > 0 || len(segments) == 0 {
+		flush()
+	}
+	for _, s := range segments {
+		if s.key == "" || (s.indexed && s.selector == "") {
+			return nil, fmt.Errorf("%w: %q: empty segment", ErrInvalidPath, path)
+		}
+	}
+	// The "tssc" root object is optional on the path.
+	if len(segments) > 1 && segments[0] == (pathSegment{key: "tssc"}) {
+		segments = segments[1:]
+	}
+	if segments[len(segments)-1].indexed {
+		return nil, fmt.Errorf("%w: %q: must end with an attribute name",
+			ErrInvalidPath, path)
+	}
+	return segments, nil
+}
+
+// selectItem returns the sequence item matching the selector, either the item
+// index or the item "name" attribute, nil when not found.
+func selectItem(seq *yaml.Node, selector string) *yaml.Node {
+	if i, err := strconv.Atoi(selector); err == nil {
+		if i < 0 || i >= len(seq.Content) {
+			return nil
+		}
+		return seq.Content[i]
+	}
+	return itemByName(seq, selector)
+}
+
+// itemByName returns the sequence item with the informed "name" attribute, nil
+// when not found.
+func itemByName(seq *yaml.Node, name string) *yaml.Node {
+	idx := slices.IndexFunc(seq.Content, func(n *yaml.Node) bool {
+		nameNode := mappingValue(n, "name")
+		return nameNode != nil && nameNode.Value == name
+	})
+	if idx < 0 {
+		return nil
+	}
+	return seq.Content[idx]
+}
+
+// walkPath walks the "tssc" object following the path segments, returns the
+// mapping node holding the last segment key. When create is informed the missing
+// mappings are created, and empty values are replaced by mappings.
+func walkPath(
+	root *yaml.Node,
+	segments []pathSegment,
+	create bool,
+) (*yaml.Node, error) {
+	node := mappingValue(root, "tssc")
+	if node == nil {
+		return nil, fmt.Errorf("%w: missing root object", ErrInvalidConfig)
+	}
+	for i, s := range segments[:len(segments)-1] {
+		location := pathString(segments[:i+1])
+		next := mappingValue(node, s.key)
+		switch {
+		case next == nil && create && !s.indexed:
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s.key},
+				next,
+			)
+		case next == nil:
+			return nil, fmt.Errorf("%w: %q not found", ErrInvalidPath, location)
+		case next.Kind == yaml.AliasNode:
+			return nil, fmt.Errorf("%w: %q is a YAML alias, edit the anchor instead",
+				ErrInvalidPath, location)
+		}
+		if s.indexed {
+			if next.Kind != yaml.SequenceNode {
+				list := append(segments[:i:i], pathSegment{key: s.key})
+				return nil, fmt.Errorf("%w: %q is not a list",
+					ErrInvalidPath, pathString(list))
+			}
+			if next = selectItem(next, s.selector); next == nil {
+				return nil, fmt.Errorf("%w: %q not found", ErrInvalidPath, location)
+			}
+		}
+		if next.Kind != yaml.MappingNode {
+			if !create || next.Kind != yaml.ScalarNode || next.Tag != "!!null" {
+				return nil, fmt.Errorf("%w: %q is not an object",
+					ErrInvalidPath, location)
+			}
+			// Empty value, replaced by a mapping keeping the comments.
+			*next = yaml.Node{
+				Kind:        yaml.MappingNode,
+				Tag:         "!!map",
+				HeadComment: next.HeadComment,
+				LineComment: next.LineComment,
+				FootComment: next.FootComment,
+			}
+		}
+		node = next
+	}
+	return node, nil
+}
+
+// pathString returns the path segments as string.
+func pathString(segments []pathSegment) string {
+	parts := make([]string, 0, len(segments))
+	for _, s := range segments {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, ".")
+}
+
+// setNode sets the value on the attribute path, the existing value is replaced
+// keeping its comments and anchor.
+func setNode(root *yaml.Node, segments []pathSegment, value *yaml.Node) error {
+	node, err := walkPath(root, segments, true)
+	if err != nil {
+		return err
+	}
+	key := segments[len(segments)-1].key
+	existing := mappingValue(node, key)
+	if existing == nil {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			value,
+		)
+		return nil
+	}
+	value.Anchor = existing.Anchor
+	value.HeadComment = existing.HeadComment
+	value.LineComment = existing.LineComment
+	value.FootComment = existing.FootComment
+	*existing = *value
+	return nil
+}
+
+// unsetNode removes the attribute path from the document.
+func unsetNode(root *yaml.Node, segments []pathSegment) error {
+	node, err := walkPath(root, segments, false)
+	if err != nil {
+		return err
+	}
+	key := segments[len(segments)-1].key
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			if node.Content[i+1].Anchor != "" {
+				return fmt.Errorf("%w: %q is a YAML anchor, it may be referenced",
+					ErrInvalidPath, pathString(segments))
+			}
+			node.Content = slices.Delete(node.Content, i, i+2)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q not found", ErrInvalidPath, pathString(segments))
+}
+
+// edit modifies the configuration payload with the informed function and reloads
+// the configuration from it. The payload must comply with the schema, otherwise
+// the previous payload is kept.
+func (c *Config) edit(fn func(root *yaml.Node) error) error {
+	previous := c.payload
+	if err := c.updatePayload(fn); err != nil {
+		return err
+	}
+	cfg, err := NewConfigFromBytes(c.payload)
+	if err != nil {
+		c.payload = previous
+		return err
+	}
+	c.Installer = cfg.Installer
+	return nil
+}
+
+// Set sets the attribute path to the informed value, parsed as YAML. For instance,
+// "true" is set as a boolean, and "[a, b]" as a list. The intermediary objects are
+// created when missing. Comments on the configuration are preserved.
+func (c *Config) Set(path, value string) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err = yaml.Unmarshal([]byte(value), &doc); err != nil {
+		return fmt.Errorf("%w: value %q: %w", ErrInvalidConfig, value, err)
+	}
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if len(doc.Content) > 0 {
+		valueNode = doc.Content[0]
+	}
+	return c.edit(func(root *yaml.Node) error {
+		return setNode(root, segments, valueNode)
+	})
+}
+
+// Unset removes the attribute path from the configuration.
+func (c *Config) Unset(path string) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	return c.edit(func(root *yaml.Node) error {
+		return unsetNode(root, segments)
+	})
+}
+
+// SetProductEnabled toggles the product by name.
+func (c *Config) SetProductEnabled(name string, enabled bool) error {
+	if _, err := c.GetProduct(name); err != nil {
+		return err
+	}
+	segments := []pathSegment{
+		{key: "products", selector: name, indexed: true},
+		{key: "enabled"},
+	}
+	return c.edit(func(root *yaml.Node) error {
+		return setNode(root, segments, &yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Tag:   "!!bool",
+			Value: strconv.FormatBool(enabled),
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestParsePath(t *testing.T) {
+	g := o.NewWithT(t)
+
+	segments, err := parsePath("tssc.products[Developer Hub].properties.authProvider")
+	g.Expect(err).To(o.Succeed())
+	g.Expect(segments).To(o.Equal([]pathSegment{
+		{key: "products", selector: "Developer Hub", indexed: true},
+		{key: "properties"},
+		{key: "authProvider"},
+	}))
+	g.Expect(pathString(segments)).
+		To(o.Equal("products[Developer Hub].properties.authProvider"))
+
+	for _, path := range []string{
+		"", "settings..crc", "products[Developer Hub", "products[]", "products[0]",
+	} {
+		_, err = parsePath(path)
+		g.Expect(err).To(o.MatchError(ErrInvalidPath), path)
+	}
+}
+
+func TestConfigEdit(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cfs, err := chartfs.NewChartFS("../../installer")
+	g.Expect(err).To(o.Succeed())
+	cfg, err := NewConfigFromFile(cfs, "config.yaml")
+	g.Expect(err).To(o.Succeed())
+
+	t.Run("Set", func(t *testing.T) {
+		g.Expect(cfg.Set("products[Developer Hub].properties.authProvider",
+			"gitlab")).To(o.Succeed())
+		g.Expect(cfg.Set("settings.ci.debug", "true")).To(o.Succeed())
+		g.Expect(cfg.Set("products[5].properties.RBAC.enabled", "true")).
+			To(o.Succeed())
+
+		product, err := cfg.GetProduct("Developer Hub")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(product.Properties).
+			To(o.HaveKeyWithValue("authProvider", "gitlab"))
+		g.Expect(cfg.Installer.Settings["ci"]).
+			To(o.HaveKeyWithValue("debug", true))
+		g.Expect(cfg.Installer.Products[5].Properties["RBAC"]).
+			To(o.HaveKeyWithValue("enabled", true))
+
+		// Comments and anchors are preserved.
+		g.Expect(cfg.String()).To(o.ContainSubstring("# Main installer namespace."))
+		g.Expect(cfg.String()).To(o.ContainSubstring(
+			"# Enables installer verbose logging messages"))
+		g.Expect(cfg.String()).To(o.ContainSubstring("*installerNamespace"))
+	})
+
+	t.Run("SetAnchor", func(t *testing.T) {
+		g.Expect(cfg.Set("namespace", "tssc-installer")).To(o.Succeed())
+		g.Expect(cfg.Installer.Namespace).To(o.Equal("tssc-installer"))
+		product, err := cfg.GetProduct(OpenShiftPipelines)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(product.GetNamespace()).To(o.Equal("tssc-installer"))
+
+		err = cfg.Set("products[OpenShift Pipelines].namespace.name", "x")
+		g.Expect(err).To(o.MatchError(ErrInvalidPath))
+	})
+
+	t.Run("SetInvalid", func(t *testing.T) {
+		payload := cfg.String()
+		err := cfg.Set("settings.crc", "yes")
+		g.Expect(err).To(o.MatchError(ErrInvalidConfig))
+		g.Expect(cfg.String()).To(o.Equal(payload))
+
+		err = cfg.Set("products[Unknown].enabled", "true")
+		g.Expect(err).To(o.MatchError(ErrInvalidPath))
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		g.Expect(cfg.Unset("settings.ci.debug")).To(o.Succeed())
+		g.Expect(cfg.Installer.Settings["ci"]).NotTo(o.HaveKey("debug"))
+
+		g.Expect(cfg.Unset("settings.ci.debug")).To(o.MatchError(ErrInvalidPath))
+		g.Expect(cfg.Unset("namespace")).To(o.MatchError(ErrInvalidPath))
+		g.Expect(cfg.Unset("settings")).To(o.MatchError(ErrInvalidConfig))
+	})
+
+	t.Run("SetProductEnabled", func(t *testing.T) {
+		name := "Trusted Profile Analyzer"
+		g.Expect(cfg.SetProductEnabled(name, false)).To(o.Succeed())
+		product, err := cfg.GetProduct(name)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(product.Enabled).To(o.BeFalse())
+		g.Expect(cfg.String()).To(o.ContainSubstring(
+			"# Red Hat Trusted Profile Analyzer (TPA)"))
+
+		g.Expect(cfg.SetProductEnabled("Unknown", true)).NotTo(o.Succeed())
+	})
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+)
+
+// ProfilesRelativeDir relative path to the directory with the configuration
+// profiles, YAML overlays on the installer configuration.
+var ProfilesRelativeDir = "installer/profiles"
+
+// ErrProfileNotFound the configuration profile is not shipped with the installer.
+var ErrProfileNotFound = errors.New("configuration profile not found")
+
+// Profile a named configuration overlay shipped with the installer.
+type Profile struct {
+	// Name the profile name, the overlay file name without extension.
+	Name string
+	// Description describes the profile, the leading comment of the overlay.
+	Description string
+	// Path the overlay file relative path.
+	Path string
+}
+
+// profileDescription returns the leading comment lines of the payload, as a
+// single line.
+func profileDescription(payload []byte) string {
+	lines := []string{}
+	for _, line := range strings.Split(string(payload), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		lines = append(lines, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+	}
+	return strings.Join(lines, " ")
+}
+
+// GetProfiles returns the configuration profiles, sorted by name.
+func GetProfiles(cfs *chartfs.ChartFS) ([]Profile, error) {
+	entries, err := cfs.ReadDir(ProfilesRelativeDir)
+	if err != nil {
+		return nil, err
+	}
+	profiles := []Profile{}
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		p := Profile{
+			Name: strings.TrimSuffix(e.Name(), ".yaml"),
+			Path: path.Join(ProfilesRelativeDir, e.Name()),
+		}
+		payload, err := cfs.ReadFile(p.Path)
+		if err != nil {
+			return nil, err
+		}
+		p.Description = profileDescription(payload)
+		profiles = append(profiles, p)
+	}
+	slices.SortFunc(profiles, func(a, b Profile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return profiles, nil
+}
+
+// GetProfile returns the configuration profile by name.
+func GetProfile(cfs *chartfs.ChartFS, name string) (*Profile, error) {
+	profiles, err := GetProfiles(cfs)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i], nil
+		}
+		names = append(names, profiles[i].Name)
+	}
+	return nil, fmt.Errorf("%w: %q, available profiles: %s",
+		ErrProfileNotFound, name, strings.Join(names, ", "))
+}
+
+// ProfilePaths returns the overlay paths of the informed profiles, in order.
+func ProfilePaths(cfs *chartfs.ChartFS, names ...string) ([]string, error) {
+	paths := []string{}
+	for _, name := range names {
+		p, err := GetProfile(cfs, name)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, p.Path)
+	}
+	return paths, nil
+}
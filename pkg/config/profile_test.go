@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestProfiles(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cfs, err := chartfs.NewChartFS("../../installer")
+	g.Expect(err).To(o.Succeed())
+
+	profiles, err := GetProfiles(cfs)
+	g.Expect(err).To(o.Succeed())
+	names := []string{}
+	for _, p := range profiles {
+		names = append(names, p.Name)
+		g.Expect(p.Description).NotTo(o.BeEmpty(), p.Name)
+
+		// Every profile is a valid overlay on the default configuration.
+		_, err = NewConfigFromFiles(cfs, "config.yaml", p.Path)
+		g.Expect(err).To(o.Succeed(), p.Name)
+	}
+	g.Expect(names).To(o.Equal([]string{"ci", "crc", "full", "minimal"}))
+
+	t.Run("CRC", func(t *testing.T) {
+		paths, err := ProfilePaths(cfs, "crc", "ci")
+		g.Expect(err).To(o.Succeed())
+		cfg, err := NewConfigFromFiles(
+			cfs, append([]string{"config.yaml"}, paths...)...)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(cfg.Installer.Settings).To(o.HaveKeyWithValue("crc", true))
+		g.Expect(cfg.Installer.Settings["ci"]).
+			To(o.HaveKeyWithValue("debug", true))
+		tpa, err := cfg.GetProduct("Trusted Profile Analyzer")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(tpa.Enabled).To(o.BeFalse())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := GetProfile(cfs, "unknown")
+		g.Expect(err).To(o.MatchError(ErrProfileNotFound))
+		g.Expect(err).To(o.MatchError(o.ContainSubstring("ci, crc, full, minimal")))
+	})
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidProperties the product properties don't comply with the chart
+// properties schema.
+var ErrInvalidProperties = errors.New("invalid product properties")
+
+// propertiesSchemaURL the location the properties schema is registered on the
+// compiler, by product name.
+const propertiesSchemaURL = "schema/properties/%s.json"
+
+// mappingValue returns the value node for the key on the mapping node, nil when
+// not found.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// updatePayload parses the configuration payload as a YAML document, calls the
+// informed function to modify it, and stores the result back on the payload.
+// Comments are preserved.
+func (c *Config) updatePayload(fn func(root *yaml.Node) error) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(c.payload, &doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrUnmarshalConfig, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return ErrEmptyConfig
+	}
+	if err := fn(doc.Content[0]); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	c.payload = append([]byte("---\n"), buf.Bytes()...)
+	return nil
+}
+
+// setProductProperty sets the product property on the configuration payload and
+// on the product specification.
+func (c *Config) setProductProperty(name, key string, value any) error {
+	product, err := c.GetProduct(name)
+	if err != nil {
+		return err
+	}
+	var valueNode yaml.Node
+	if err = valueNode.Encode(value); err != nil {
+		return err
+	}
+	segments := []pathSegment{
+		{key: "products", selector: name, indexed: true},
+		{key: "properties"},
+		{key: key},
+	}
+	err = c.updatePayload(func(root *yaml.Node) error {
+		return setNode(root, segments, &valueNode)
+	})
+	if err != nil {
+		return err
+	}
+	if product.Properties == nil {
+		product.Properties = map[string]interface{}{}
+	}
+	product.Properties[key] = value
+	return nil
+}
+
+// mergePropertiesSchemas combines the properties schemas shipped by the charts
+// of a single product into one object schema, so each chart properties are
+// known to the others. Properties declared by more than one chart must comply
+// with all declarations, the first default informed is employed. The merged
+// schema rejects unknown properties when any of the schemas does.
+func mergePropertiesSchemas(
+	schemas [][]byte,
+) (map[string]any, map[string]any, error) {
+	properties := map[string]any{}
+	defaults := map[string]any{}
+	required := []any{}
+	closed := false
+	for _, schema := range schemas {
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+		if err != nil {
+			return nil, nil, err
+		}
+		root, ok := doc.(map[string]any)
+		if !ok {
+			return nil, nil, errors.New("schema must be an object")
+		}
+		if additional, ok := root["additionalProperties"].(bool); ok && !additional {
+			closed = true
+		}
+		if list, ok := root["required"].([]any); ok {
+			required = append(required, list...)
+		}
+		known, _ := root["properties"].(map[string]any)
+		for key, spec := range known {
+			if value, ok := spec.(map[string]any)["default"]; ok {
+				if _, exists := defaults[key]; !exists {
+					defaults[key] = value
+				}
+			}
+			if existing, exists := properties[key]; exists {
+				spec = map[string]any{"allOf": []any{existing, spec}}
+			}
+			properties[key] = spec
+		}
+	}
+	merged := map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	if closed {
+		merged["additionalProperties"] = false
+	}
+	return merged, defaults, nil
+}
+
+// ApplyPropertiesSchema validates the product properties against the JSON
+// schemas shipped by the product charts, merged together. The top-level
+// properties missing on the product are filled with the schema defaults.
+func (c *Config) ApplyPropertiesSchema(name string, schemas ...[]byte) error {
+	product, err := c.GetProduct(name)
+	if err != nil {
+		return err
+	}
+
+	doc, defaults, err := mergePropertiesSchemas(schemas)
+	if err != nil {
+		return fmt.Errorf("%w: product %q: schema: %w",
+			ErrInvalidProperties, name, err)
+	}
+	url := fmt.Sprintf(propertiesSchemaURL, product.KeyName())
+	compiler := jsonschema.NewCompiler()
+	if err = compiler.AddResource(url, doc); err != nil {
+		return err
+	}
+	sch, err := compiler.Compile(url)
+	if err != nil {
+		return fmt.Errorf("%w: product %q: schema: %w",
+			ErrInvalidProperties, name, err)
+	}
+
+	// Filling in the defaults for the properties not informed.
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if _, exists := product.Properties[key]; exists {
+			continue
+		}
+		value := defaults[key]
+		if n, ok := value.(json.Number); ok {
+			if value, err = n.Int64(); err != nil {
+				value, _ = n.Float64()
+			}
+		}
+		if err = c.setProductProperty(name, key, value); err != nil {
+			return err
+		}
+	}
+
+	// Validating the properties, converted to the data types the validator
+	// expects.
+	payload, err := json.Marshal(product.Properties)
+	if err != nil {
+		return err
+	}
+	if product.Properties == nil {
+		payload = []byte("{}")
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	if err = sch.Validate(instance); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+		messages := []string{}
+		for _, leaf := range leafErrors(validationErr) {
+			path := "/" + strings.Join(leaf.InstanceLocation, "/")
+			// Unknown properties are reported one by one.
+			if k, ok := leaf.ErrorKind.(*kind.AdditionalProperties); ok {
+				for _, property := range k.Properties {
+					messages = append(messages, fmt.Sprintf("%s: unknown attribute",
+						strings.TrimSuffix(path, "/")+"/"+property))
+				}
+				continue
+			}
+			messages = append(messages, fmt.Sprintf("%s: %s",
+				path, leaf.ErrorKind.LocalizedString(schemaPrinter)))
+		}
+		return fmt.Errorf("%w: product %q: %s",
+			ErrInvalidProperties, name, strings.Join(messages, "; "))
+	}
+	return nil
+}
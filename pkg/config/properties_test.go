@@ -0,0 +1,108 @@
+package config
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+const testPropertiesSchema = `{
+  "type": "object",
+  "required": ["authProvider"],
+  "additionalProperties": false,
+  "properties": {
+    "authProvider": {"enum": ["github", "gitlab"]},
+    "manageSubscription": {"type": "boolean", "default": true},
+    "replicas": {"type": "integer", "default": 2}
+  }
+}`
+
+func TestApplyPropertiesSchema(t *testing.T) {
+	g := o.NewWithT(t)
+
+	newConfig := func(properties string) *Config {
+		cfg, err := NewConfigFromBytes([]byte(`---
+tssc:
+  namespace: tssc
+  settings: {}
+  products:
+    # Product comment.
+    - name: Product
+      enabled: true
+      namespace: product
+      properties:` + properties + "\n"))
+		g.Expect(err).To(o.Succeed())
+		return cfg
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := newConfig("\n        authProvider: github")
+		g.Expect(cfg.ApplyPropertiesSchema("Product", []byte(testPropertiesSchema))).
+			To(o.Succeed())
+
+		product, err := cfg.GetProduct("Product")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(product.Properties).To(o.Equal(map[string]interface{}{
+			"authProvider":       "github",
+			"manageSubscription": true,
+			"replicas":           int64(2),
+		}))
+		g.Expect(cfg.String()).To(o.ContainSubstring("# Product comment."))
+		g.Expect(cfg.String()).To(o.ContainSubstring("manageSubscription: true"))
+		g.Expect(cfg.String()).To(o.ContainSubstring("replicas: 2"))
+
+		// The payload is a valid configuration carrying the defaults.
+		reloaded, err := NewConfigFromBytes([]byte(cfg.String()))
+		g.Expect(err).To(o.Succeed())
+		reloadedProduct, err := reloaded.GetProduct("Product")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(reloadedProduct.Properties).To(o.HaveKeyWithValue("replicas", 2))
+	})
+
+	t.Run("UnknownProperties", func(t *testing.T) {
+		cfg := newConfig("\n        authProvider: github\n        replica: 3")
+		err := cfg.ApplyPropertiesSchema("Product", []byte(testPropertiesSchema))
+		g.Expect(err).To(o.MatchError(ErrInvalidProperties))
+		g.Expect(err).To(o.MatchError(
+			o.ContainSubstring("/replica: unknown attribute")))
+	})
+
+	t.Run("MultipleCharts", func(t *testing.T) {
+		addonSchema := []byte(`{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "addon": {"type": "boolean", "default": false},
+    "replicas": {"minimum": 1}
+  }
+}`)
+		cfg := newConfig("\n        authProvider: github\n        addon: true")
+		g.Expect(cfg.ApplyPropertiesSchema(
+			"Product", []byte(testPropertiesSchema), addonSchema,
+		)).To(o.Succeed())
+		product, err := cfg.GetProduct("Product")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(product.Properties).To(o.HaveKeyWithValue("addon", true))
+		g.Expect(product.Properties).To(o.HaveKeyWithValue("replicas", int64(2)))
+
+		// Both declarations of a shared property apply.
+		cfg = newConfig("\n        authProvider: github\n        replicas: 0")
+		err = cfg.ApplyPropertiesSchema(
+			"Product", []byte(testPropertiesSchema), addonSchema)
+		g.Expect(err).To(o.MatchError(ErrInvalidProperties))
+		g.Expect(err).To(o.MatchError(o.ContainSubstring("/replicas")))
+
+		cfg = newConfig("\n        authProvider: github\n        other: true")
+		err = cfg.ApplyPropertiesSchema(
+			"Product", []byte(testPropertiesSchema), addonSchema)
+		g.Expect(err).To(o.MatchError(
+			o.ContainSubstring("/other: unknown attribute")))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		cfg := newConfig(" {}")
+		err := cfg.ApplyPropertiesSchema("Product", []byte(testPropertiesSchema))
+		g.Expect(err).To(o.MatchError(ErrInvalidProperties))
+		g.Expect(err).To(o.MatchError(o.ContainSubstring("authProvider")))
+	})
+}
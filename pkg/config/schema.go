@@ -0,0 +1,211 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion the configuration schema version enforced by the installer.
+const SchemaVersion = "v1"
+
+// schemaV1 the configuration JSON schema, version "v1".
+//
+//go:embed schema/v1.json
+var schemaV1 []byte
+
+// schemaURL the location the schema is registered on the compiler.
+const schemaURL = "schema/config/v1.json"
+
+// compileSchema compiles the embedded configuration schema once.
+var compileSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaV1))
+	if err != nil {
+		return nil, err
+	}
+	c := jsonschema.NewCompiler()
+	if err = c.AddResource(schemaURL, doc); err != nil {
+		return nil, err
+	}
+	return c.Compile(schemaURL)
+})
+
+// schemaPrinter prints the schema validation messages.
+var schemaPrinter = message.NewPrinter(language.English)
+
+// SchemaViolation describes a configuration attribute violating the schema.
+type SchemaViolation struct {
+	// Line the line number in the configuration payload, zero when unknown.
+	Line int
+	// Path the attribute path, e.g. "/tssc/products/0/enabled".
+	Path string
+	// Message describes the violation.
+	Message string
+}
+
+// String returns the violation as a single line.
+func (v SchemaViolation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "/"
+	}
+	if v.Line == 0 {
+		return fmt.Sprintf("%s: %s", path, v.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", v.Line, path, v.Message)
+}
+
+// lookupNode walks the YAML document following the attribute path, returns the
+// deepest node found, and the key node when the path ends on a mapping key.
+func lookupNode(doc *yaml.Node, path []string) *yaml.Node {
+	node := doc
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, token := range path {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+// keyLine returns the line of the key on the mapping node, zero when not found.
+func keyLine(node *yaml.Node, key string) int {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return 0
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i].Line
+		}
+	}
+	return 0
+}
+
+// leafErrors flattens the validation error tree, returns the innermost causes.
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	leaves := []*jsonschema.ValidationError{}
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
+
+// ValidateSchema validates the configuration payload against the schema, returns
+// the violations found sorted by line. The error is returned when the payload is
+// not valid YAML.
+func ValidateSchema(payload []byte) ([]SchemaViolation, error) {
+	sch, err := compileSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err = yaml.Unmarshal(payload, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshalConfig, err)
+	}
+	// The instance is converted to JSON, the data types the validator expects.
+	var obj interface{}
+	if err = doc.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshalConfig, err)
+	}
+	instance, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshalConfig, err)
+	}
+	v, err := jsonschema.UnmarshalJSON(bytes.NewReader(instance))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshalConfig, err)
+	}
+
+	violations := []SchemaViolation{}
+	var validationErr *jsonschema.ValidationError
+	err = sch.Validate(v)
+	if err == nil {
+		return violations, nil
+	}
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+	for _, leaf := range leafErrors(validationErr) {
+		node := lookupNode(&doc, leaf.InstanceLocation)
+		violation := SchemaViolation{
+			Line:    node.Line,
+			Path:    "/" + strings.Join(leaf.InstanceLocation, "/"),
+			Message: leaf.ErrorKind.LocalizedString(schemaPrinter),
+		}
+		// Unknown attributes are reported on the line of the attribute itself.
+		if k, ok := leaf.ErrorKind.(*kind.AdditionalProperties); ok {
+			for _, property := range k.Properties {
+				v := violation
+				if line := keyLine(node, property); line > 0 {
+					v.Line = line
+				}
+				v.Path = strings.TrimSuffix(v.Path, "/") + "/" + property
+				v.Message = "unknown attribute"
+				violations = append(violations, v)
+			}
+			continue
+		}
+		violations = append(violations, violation)
+	}
+	slices.SortStableFunc(violations, func(a, b SchemaViolation) int {
+		return a.Line - b.Line
+	})
+	return violations, nil
+}
+
+// validateSchema validates the configuration payload against the schema, the
+// violations are returned as a single error.
+func validateSchema(payload []byte) error {
+	violations, err := ValidateSchema(payload)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	messages := []string{}
+	for _, v := range violations {
+		messages = append(messages, v.String())
+	}
+	return fmt.Errorf("%w: schema %s: %s",
+		ErrInvalidConfig, SchemaVersion, strings.Join(messages, "; "))
+}
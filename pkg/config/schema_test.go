@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestValidateSchema(t *testing.T) {
+	g := o.NewWithT(t)
+
+	t.Run("Embedded", func(t *testing.T) {
+		cfs, err := chartfs.NewChartFS("../../installer")
+		g.Expect(err).To(o.Succeed())
+		payload, err := cfs.ReadFile("config.yaml")
+		g.Expect(err).To(o.Succeed())
+
+		violations, err := ValidateSchema(payload)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(violations).To(o.BeEmpty())
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		violations, err := ValidateSchema([]byte(`---
+tssc:
+  namespace: tssc
+  settings:
+    crc: "yes"
+  products:
+    - name: Developer Hub
+      enable: true
+      namespace: tssc-dh
+      properties:
+        manageSubscriptions: true
+        authProvider: bitbucket
+`))
+		g.Expect(err).To(o.Succeed())
+
+		lines := []string{}
+		for _, v := range violations {
+			lines = append(lines, v.String())
+		}
+		g.Expect(lines).To(o.HaveLen(4))
+		g.Expect(lines[0]).To(o.HavePrefix("line 5: /tssc/settings/crc: "))
+		g.Expect(lines[1]).To(o.Equal(
+			"line 8: /tssc/products/0/enable: unknown attribute"))
+		g.Expect(lines[2]).To(o.Equal(
+			"line 11: /tssc/products/0/properties/manageSubscriptions: " +
+				"unknown attribute"))
+		g.Expect(lines[3]).To(o.HavePrefix(
+			"line 12: /tssc/products/0/properties/authProvider: "))
+	})
+
+	t.Run("NewConfigFromBytes", func(t *testing.T) {
+		_, err := NewConfigFromBytes([]byte(`
+tssc:
+  namespace: tssc
+  settings: {}
+  unknown: true
+`))
+		g.Expect(err).To(o.MatchError(ErrInvalidConfig))
+		g.Expect(err).To(o.MatchError(o.ContainSubstring("line 5")))
+	})
+
+	t.Run("InvalidYAML", func(t *testing.T) {
+		_, err := ValidateSchema([]byte("tssc: [\n"))
+		g.Expect(err).To(o.MatchError(ErrUnmarshalConfig))
+	})
+}
@@ -1,6 +1,9 @@
 package constants
 
-import "fmt"
+import (
+	"fmt"
+	"runtime/debug"
+)
 
 const (
 	// AppName is the name of the application.
@@ -17,3 +20,12 @@ var (
 	// RepoURI is the reverse repository URI for the application.
 	RepoURI = fmt.Sprintf("%s.%s.%s", AppName, OrgName, Domain)
 )
+
+// Version the application version, from the executable build information.
+var Version = func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" {
+		return "unknown"
+	}
+	return info.Main.Version
+}()
@@ -7,17 +7,32 @@ import (
 	"syscall"
 )
 
-func backgroundContext(fn func()) context.Context {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
+// backgroundContext returns a context cancelled on interrupt signals, the
+// informed function is called before cancelling.
+func backgroundContext(fn func()) (context.Context, context.CancelFunc) {
+	return signalContext(context.Background(), fn)
+}
+
+// signalContext returns a context derived from the parent context, cancelled on
+// interrupt signals as well, the informed function is called before cancelling.
+// The returned cancel function must be called to stop watching the signals.
+func signalContext(
+	parent context.Context,
+	fn func(),
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
 
 	signalCh := make(chan os.Signal, 2)
 	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		<-signalCh
-		fn()
-		cancel()
+		defer signal.Stop(signalCh)
+		select {
+		case <-signalCh:
+			fn()
+			cancel()
+		case <-ctx.Done():
+		}
 	}()
 
-	return ctx
+	return ctx, cancel
 }
@@ -0,0 +1,50 @@
+package deployer
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestSignalContext(t *testing.T) {
+	g := o.NewWithT(t)
+
+	// The first signal registration starts the runtime signal watcher, which
+	// lives on, thus it's started before counting the goroutines.
+	_, cancel := signalContext(context.Background(), func() {})
+	cancel()
+
+	// released asserts the signal watchers stopped, once the contexts are done.
+	released := func(baseline int) {
+		g.Eventually(runtime.NumGoroutine).
+			WithTimeout(time.Second).
+			Should(o.BeNumerically("<=", baseline))
+	}
+
+	t.Run("Cancel", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+		cancels := []context.CancelFunc{}
+		for range 10 {
+			ctx, cancel := signalContext(context.Background(), func() {})
+			g.Expect(ctx.Err()).To(o.Succeed())
+			cancels = append(cancels, cancel)
+		}
+		for _, cancel := range cancels {
+			cancel()
+		}
+		released(baseline)
+	})
+
+	t.Run("Parent", func(t *testing.T) {
+		baseline := runtime.NumGoroutine()
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := signalContext(parent, func() {})
+		defer cancel()
+		cancelParent()
+		g.Eventually(ctx.Done()).Should(o.BeClosed())
+		released(baseline)
+	})
+}
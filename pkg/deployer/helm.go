@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"time"
@@ -28,6 +29,7 @@ import (
 type Helm struct {
 	logger *slog.Logger // application logger
 	flags  *flags.Flags // global flags
+	out    io.Writer    // release information output
 
 	chart     *chart.Chart          // helm chart instance
 	namespace string                // kubernetes namespace
@@ -42,39 +44,51 @@ var ErrInstallFailed = errors.New("install failed")
 // ErrUpgradeFailed when the Helm chart upgrade fails.
 var ErrUpgradeFailed = errors.New("upgrade failed")
 
+// ErrUninstallFailed when the Helm chart uninstall fails.
+var ErrUninstallFailed = errors.New("uninstall failed")
+
+// ErrRollbackFailed when the Helm chart rollback fails.
+var ErrRollbackFailed = errors.New("rollback failed")
+
 // printRelease prints the Helm release information.
 func (h *Helm) printRelease(rel *release.Release) {
 	// In debug mode, print the configuration values using key-value pairs.
 	if !h.flags.DryRun && h.flags.Debug {
-		printer.ValuesPrinter("Config", rel.Config)
+		printer.ValuesPrinter(h.out, "Config", rel.Config)
 	}
-	printer.HelmReleasePrinter(rel)
+	printer.HelmReleasePrinter(h.out, rel)
 	// Print extended release information only in dry-run or debug mode. This
 	// allows rendering chart templates (dry-run) while inspecting the release
 	// manifests.
 	if h.flags.DryRun || h.flags.Debug {
-		printer.HelmExtendedReleasePrinter(rel)
+		printer.HelmExtendedReleasePrinter(h.out, rel)
 	}
-	printer.HelmReleaseNotesPrinter(rel)
+	printer.HelmReleaseNotesPrinter(h.out, rel)
 }
 
-// helmInstall equivalent to "helm install" command.
-func (h *Helm) helmInstall(vals chartutil.Values) (*release.Release, error) {
+// helmInstall equivalent to "helm install" command, dry-run simulates the
+// installation against the cluster.
+func (h *Helm) helmInstall(
+	ctx context.Context,
+	vals chartutil.Values,
+	dryRun bool,
+) (*release.Release, error) {
 	c := action.NewInstall(h.actionCfg)
 	c.GenerateName = false
 	c.Namespace = h.namespace
 	c.ReleaseName = h.chart.Name()
 	c.Timeout = h.flags.Timeout
 
-	c.DryRun = h.flags.DryRun
-	c.ClientOnly = h.flags.DryRun
-	if h.flags.DryRun {
+	c.DryRun = dryRun
+	c.ClientOnly = dryRun
+	if dryRun {
 		c.DryRunOption = "server"
 	}
 
-	ctx := backgroundContext(func() {
+	ctx, cancel := signalContext(ctx, func() {
 		h.logger.Warn("Release installation has been cancelled.")
 	})
+	defer cancel()
 
 	rel, err := c.RunWithContext(ctx, h.chart, vals)
 	if err != nil {
@@ -83,20 +97,29 @@ func (h *Helm) helmInstall(vals chartutil.Values) (*release.Release, error) {
 	return rel, nil
 }
 
-// helmUpgrade equivalent to "helm upgrade" command.
-func (h *Helm) helmUpgrade(vals chartutil.Values) (*release.Release, error) {
+// helmUpgrade equivalent to "helm upgrade" command, dry-run simulates the upgrade
+// against the cluster.
+func (h *Helm) helmUpgrade(
+	ctx context.Context,
+	vals chartutil.Values,
+	dryRun bool,
+) (*release.Release, error) {
 	c := action.NewUpgrade(h.actionCfg)
 	c.Namespace = h.namespace
 	c.Timeout = h.flags.Timeout
+	// The digest of the previous release doesn't describe the upgrade, it's
+	// removed until the upgrade is verified, see SetDigest.
+	c.Labels = map[string]string{DigestLabel: "null"}
 
-	c.DryRun = h.flags.DryRun
-	if h.flags.DryRun {
+	c.DryRun = dryRun
+	if dryRun {
 		c.DryRunOption = "server"
 	}
 
-	ctx := backgroundContext(func() {
+	ctx, cancel := signalContext(ctx, func() {
 		h.logger.Warn("Release upgrade has been cancelled.")
 	})
+	defer cancel()
 
 	rel, err := c.RunWithContext(ctx, h.chart.Name(), h.chart, vals)
 	if err != nil {
@@ -105,9 +128,36 @@ func (h *Helm) helmUpgrade(vals chartutil.Values) (*release.Release, error) {
 	return rel, err
 }
 
+// SetDigest stores the digest label on the release deployed, only verified
+// releases should carry it, Plan relies on the digest to skip unchanged releases.
+func (h *Helm) SetDigest(digest string) error {
+	if h.flags.DryRun || h.release == nil {
+		return nil
+	}
+	if h.release.Labels == nil {
+		h.release.Labels = map[string]string{}
+	}
+	h.release.Labels[DigestLabel] = digest
+	return h.actionCfg.Releases.Update(h.release)
+}
+
+// Plan determines the action Deploy performs for the informed release digest,
+// returns the current release as well, nil when not deployed.
+func (h *Helm) Plan(digest string) (Action, *release.Release, error) {
+	current, err := h.GetRelease()
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return PlanAction(nil, digest), nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return PlanAction(current, digest), current, nil
+}
+
 // Deploy deploys the Helm chart (Dependency) on the cluster. It checks if the
 // release is already installed in order to use the proper helm-client (action).
-func (h *Helm) Deploy(vals chartutil.Values) error {
+// Cancelling the context interrupts the installation.
+func (h *Helm) Deploy(ctx context.Context, vals chartutil.Values) error {
 	c := action.NewHistory(h.actionCfg)
 	c.Max = 1
 
@@ -115,10 +165,10 @@ func (h *Helm) Deploy(vals chartutil.Values) error {
 	var err error
 	if _, err = c.Run(h.chart.Name()); errors.Is(err, driver.ErrReleaseNotFound) {
 		h.logger.Info("Installing Helm Chart...")
-		h.release, err = h.helmInstall(vals)
+		h.release, err = h.helmInstall(ctx, vals, h.flags.DryRun)
 	} else {
 		h.logger.Info("Upgrading Helm Chart...")
-		h.release, err = h.helmUpgrade(vals)
+		h.release, err = h.helmUpgrade(ctx, vals, h.flags.DryRun)
 	}
 	if err != nil {
 		return err
@@ -127,6 +177,85 @@ func (h *Helm) Deploy(vals chartutil.Values) error {
 	return nil
 }
 
+// Render renders the Helm chart with the informed values, simulating the install
+// or upgrade against the cluster, without changes. Returns the current release,
+// nil when not deployed, and the simulated release.
+func (h *Helm) Render(
+	vals chartutil.Values,
+) (*release.Release, *release.Release, error) {
+	current, err := h.GetRelease()
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		h.logger.Debug("Rendering Helm Chart installation...")
+		rendered, err := h.helmInstall(context.Background(), vals, true)
+		return nil, rendered, err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	h.logger.Debug("Rendering Helm Chart upgrade...")
+	rendered, err := h.helmUpgrade(context.Background(), vals, true)
+	return current, rendered, err
+}
+
+// GetRelease equivalent to "helm get", returns the current release of the Helm
+// chart, or "driver.ErrReleaseNotFound" when not deployed.
+func (h *Helm) GetRelease() (*release.Release, error) {
+	return action.NewGet(h.actionCfg).Run(h.chart.Name())
+}
+
+// History equivalent to "helm history", returns all the release revisions of the
+// Helm chart, empty when not deployed.
+func (h *Helm) History() ([]*release.Release, error) {
+	history, err := action.NewHistory(h.actionCfg).Run(h.chart.Name())
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return []*release.Release{}, nil
+	}
+	return history, err
+}
+
+// Uninstall equivalent to "helm uninstall" command, waits for the release
+// resources to be deleted.
+func (h *Helm) Uninstall() error {
+	c := action.NewUninstall(h.actionCfg)
+	c.Timeout = h.flags.Timeout
+	c.Wait = true
+	c.DryRun = h.flags.DryRun
+
+	h.logger.Info("Uninstalling Helm Chart...")
+	res, err := c.Run(h.chart.Name())
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUninstallFailed, err.Error())
+	}
+	if res != nil && res.Info != "" {
+		fmt.Fprintln(h.out, res.Info)
+	}
+	return nil
+}
+
+// Rollback equivalent to "helm rollback" command, rolls the release back to the
+// informed revision and waits for the resources to be ready.
+func (h *Helm) Rollback(revision int) error {
+	c := action.NewRollback(h.actionCfg)
+	c.Version = revision
+	c.Timeout = h.flags.Timeout
+	c.Wait = true
+	c.DryRun = h.flags.DryRun
+
+	h.logger.Info("Rolling back Helm Chart...", "revision", revision)
+	if err := c.Run(h.chart.Name()); err != nil {
+		return fmt.Errorf("%w: %s", ErrRollbackFailed, err.Error())
+	}
+	return nil
+}
+
+// Revision returns the deployed release revision, zero when not deployed.
+func (h *Helm) Revision() int {
+	if h.release == nil {
+		return 0
+	}
+	return h.release.Version
+}
+
 // Verify equivalent to "helm test", it checks whether the release is correctly
 // deployed by running chart tests and waiting for successful result.
 func (h *Helm) Verify() error {
@@ -180,13 +309,14 @@ func (h *Helm) VisitReleaseResources(
 
 // NewHelm creates a new Helm instance, setting up the Helm action configuration
 // to be used on subsequent interactions. The Helm instance is bound to a single
-// Helm Chart.
+// Helm Chart, and the release information is printed on the informed writer.
 func NewHelm(
 	logger *slog.Logger,
 	f *flags.Flags,
 	kube *k8s.Kube,
 	namespace string,
 	chart *chart.Chart,
+	out io.Writer,
 ) (*Helm, error) {
 	actionCfg := new(action.Configuration)
 	getter := kube.RESTClientGetter(namespace)
@@ -213,6 +343,7 @@ func NewHelm(
 			"namespace", namespace,
 		),
 		flags:     f,
+		out:       out,
 		chart:     chart,
 		namespace: namespace,
 		actionCfg: actionCfg,
@@ -0,0 +1,84 @@
+package deployer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+
+	o "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// newTestHelm instantiates the Helm client with in-memory release storage and a
+// fake Kubernetes client.
+func newTestHelm() *Helm {
+	return &Helm{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		flags:  flags.NewFlags(),
+		out:    io.Discard,
+		chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       "test",
+				Version:    "0.1.0",
+			},
+			Templates: []*chart.File{{
+				Name: "templates/configmap.yaml",
+				Data: []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  key: {{ .Values.key | quote }}
+`),
+			}},
+		},
+		namespace: "test",
+		actionCfg: &action.Configuration{
+			Releases:     storage.Init(driver.NewMemory()),
+			KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+			Capabilities: chartutil.DefaultCapabilities,
+			Log:          func(string, ...interface{}) {},
+		},
+	}
+}
+
+func TestHelmSetDigest(t *testing.T) {
+	g := o.NewWithT(t)
+	ctx := context.Background()
+	h := newTestHelm()
+
+	plan := func(digest string) Action {
+		action, _, err := h.Plan(digest)
+		g.Expect(err).To(o.Succeed())
+		return action
+	}
+
+	g.Expect(plan("a")).To(o.Equal(ActionInstall))
+	g.Expect(h.Deploy(ctx, chartutil.Values{"key": "a"})).To(o.Succeed())
+	g.Expect(h.SetDigest("a")).To(o.Succeed())
+	g.Expect(plan("a")).To(o.Equal(ActionUnchanged))
+
+	t.Run("VerificationFailed", func(t *testing.T) {
+		// Deployed, but verification failed, the digest isn't recorded.
+		g.Expect(h.Deploy(ctx, chartutil.Values{"key": "b"})).To(o.Succeed())
+		g.Expect(plan("b")).To(o.Equal(ActionUpgrade))
+		// The previous digest no longer describes the release either.
+		g.Expect(plan("a")).To(o.Equal(ActionUpgrade))
+	})
+
+	t.Run("Verified", func(t *testing.T) {
+		g.Expect(h.Deploy(ctx, chartutil.Values{"key": "b"})).To(o.Succeed())
+		g.Expect(h.SetDigest("b")).To(o.Succeed())
+		g.Expect(plan("b")).To(o.Equal(ActionUnchanged))
+	})
+}
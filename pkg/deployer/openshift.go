@@ -29,9 +29,10 @@ func EnsureOpenShiftProject(
 		return err
 	}
 
-	ctx := backgroundContext(func() {
+	ctx, cancel := backgroundContext(func() {
 		logger.Warn("Project creation has been cancelled.")
 	})
+	defer cancel()
 
 	logger.Debug("ensuring project exists.")
 	_, err = projectClient.Projects().Get(ctx, projectName, metav1.GetOptions{})
@@ -0,0 +1,37 @@
+package deployer
+
+import (
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// Action the Helm action a deployment performs on a chart release.
+type Action string
+
+const (
+	// ActionInstall the release doesn't exist, the chart is installed.
+	ActionInstall Action = "install"
+	// ActionUpgrade the release exists, the chart or values have changed.
+	ActionUpgrade Action = "upgrade"
+	// ActionUnchanged the release is deployed with the same chart and values.
+	ActionUnchanged Action = "unchanged"
+)
+
+// DigestLabel the Helm release label storing the digest of the chart content
+// and rendered values deployed.
+const DigestLabel = "tssc.redhat-appstudio.github.com/digest"
+
+// PlanAction determines the action to deploy the chart with the informed digest,
+// based on the current release, nil when not deployed. Releases not successfully
+// deployed are always upgraded.
+func PlanAction(current *release.Release, digest string) Action {
+	switch {
+	case current == nil:
+		return ActionInstall
+	case current.Info == nil || current.Info.Status != release.StatusDeployed:
+		return ActionUpgrade
+	case current.Labels[DigestLabel] == digest:
+		return ActionUnchanged
+	default:
+		return ActionUpgrade
+	}
+}
@@ -0,0 +1,30 @@
+package deployer
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func TestPlanAction(t *testing.T) {
+	g := o.NewWithT(t)
+
+	newRelease := func(status release.Status, digest string) *release.Release {
+		return &release.Release{
+			Info:   &release.Info{Status: status},
+			Labels: map[string]string{DigestLabel: digest},
+		}
+	}
+
+	g.Expect(PlanAction(nil, "a")).To(o.Equal(ActionInstall))
+	g.Expect(PlanAction(newRelease(release.StatusDeployed, "a"), "a")).
+		To(o.Equal(ActionUnchanged))
+	g.Expect(PlanAction(newRelease(release.StatusDeployed, "a"), "b")).
+		To(o.Equal(ActionUpgrade))
+	g.Expect(PlanAction(newRelease(release.StatusFailed, "a"), "a")).
+		To(o.Equal(ActionUpgrade))
+	g.Expect(PlanAction(&release.Release{
+		Info: &release.Info{Status: release.StatusDeployed},
+	}, "a")).To(o.Equal(ActionUpgrade))
+}
@@ -0,0 +1,173 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Redacted replaces the Secret data values.
+	Redacted = "<redacted>"
+	// RedactedChanged replaces the Secret data values changed by the rendered
+	// manifest.
+	RedactedChanged = "<redacted, changed>"
+)
+
+// ErrInvalidManifest the Helm release manifest can't be parsed.
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// ResourceDiff the unified diff of a single Kubernetes resource, between the
+// deployed and the rendered manifests.
+type ResourceDiff struct {
+	// Resource identifies the resource, as "Kind/namespace/name", or "Kind/name"
+	// when the namespace is not informed.
+	Resource string
+	// Diff the unified diff, the deployed resource is empty when added, and the
+	// rendered resource is empty when removed.
+	Diff string
+}
+
+// resourceKey identifies the Kubernetes resource.
+func resourceKey(obj map[string]any) string {
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	if namespace == "" {
+		return fmt.Sprintf("%s/%s", kind, name)
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// parseManifest parses the Helm release manifest, a stream of YAML documents,
+// returns the resources by key.
+func parseManifest(manifest string) (map[string]map[string]any, error) {
+	resources := map[string]map[string]any{}
+	dec := yaml.NewDecoder(strings.NewReader(manifest))
+	for {
+		obj := map[string]any{}
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidManifest, err)
+		}
+		// Empty documents, only comments for instance.
+		if len(obj) == 0 {
+			continue
+		}
+		resources[resourceKey(obj)] = obj
+	}
+	return resources, nil
+}
+
+// redactSecret replaces the Secret data values on both resources, the values
+// changed by the rendered resource are marked as such. Other kinds are ignored.
+func redactSecret(deployed, rendered map[string]any) {
+	for _, obj := range []map[string]any{deployed, rendered} {
+		if obj != nil && obj["kind"] != "Secret" {
+			return
+		}
+	}
+	for _, attr := range []string{"data", "stringData"} {
+		deployedData, _ := deployed[attr].(map[string]any)
+		renderedData, _ := rendered[attr].(map[string]any)
+		for k, v := range renderedData {
+			if dv, exists := deployedData[k]; exists && dv == v {
+				renderedData[k] = Redacted
+			} else {
+				renderedData[k] = RedactedChanged
+			}
+		}
+		for k := range deployedData {
+			deployedData[k] = Redacted
+		}
+	}
+}
+
+// toYAML serializes the resource, empty when nil.
+func toYAML(obj map[string]any) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	var buf strings.Builder
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(obj); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Manifests compares the deployed and rendered Helm release manifests, returns
+// the unified diff of each changed resource, sorted by resource. The Secret data
+// values are redacted.
+func Manifests(deployed, rendered string) ([]ResourceDiff, error) {
+	deployedResources, err := parseManifest(deployed)
+	if err != nil {
+		return nil, err
+	}
+	renderedResources, err := parseManifest(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := slices.Collect(maps.Keys(deployedResources))
+	for key := range renderedResources {
+		if _, exists := deployedResources[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	diffs := []ResourceDiff{}
+	for _, key := range keys {
+		a, b := deployedResources[key], renderedResources[key]
+		redactSecret(a, b)
+		aYAML, err := toYAML(a)
+		if err != nil {
+			return nil, err
+		}
+		bYAML, err := toYAML(b)
+		if err != nil {
+			return nil, err
+		}
+		if aYAML == bYAML {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(aYAML),
+			B:        difflib.SplitLines(bYAML),
+			FromFile: fmt.Sprintf("deployed/%s", key),
+			ToFile:   fmt.Sprintf("rendered/%s", key),
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, ResourceDiff{Resource: key, Diff: diff})
+	}
+	return diffs, nil
+}
+
+// Print prints the resource diffs to the writer.
+func Print(w io.Writer, diffs []ResourceDiff) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Fprint(w, d.Diff)
+	}
+}
@@ -0,0 +1,105 @@
+package diff
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+const deployedManifest = `---
+# Source: tssc-test/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: tssc
+data:
+  mode: minimal
+---
+# Source: tssc-test/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: tssc
+data:
+  password: c2VjcmV0
+  username: YWRtaW4=
+---
+# Source: tssc-test/templates/role.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: tssc-test
+`
+
+const renderedManifest = `---
+# Source: tssc-test/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: tssc
+data:
+  mode: full
+---
+# Source: tssc-test/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: tssc
+data:
+  password: Y2hhbmdlZA==
+  username: YWRtaW4=
+---
+# Source: tssc-test/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: tssc-test
+  namespace: tssc
+`
+
+func TestManifests(t *testing.T) {
+	g := o.NewWithT(t)
+
+	diffs, err := Manifests(deployedManifest, renderedManifest)
+	g.Expect(err).To(o.Succeed())
+
+	resources := []string{}
+	for _, d := range diffs {
+		resources = append(resources, d.Resource)
+	}
+	g.Expect(resources).To(o.Equal([]string{
+		"ClusterRole/tssc-test",
+		"ConfigMap/tssc/settings",
+		"Secret/tssc/credentials",
+		"Service/tssc/tssc-test",
+	}))
+
+	t.Run("Changed", func(t *testing.T) {
+		g.Expect(diffs[1].Diff).To(o.ContainSubstring("-  mode: minimal"))
+		g.Expect(diffs[1].Diff).To(o.ContainSubstring("+  mode: full"))
+	})
+
+	t.Run("AddedAndRemoved", func(t *testing.T) {
+		g.Expect(diffs[0].Diff).To(o.ContainSubstring("-kind: ClusterRole"))
+		g.Expect(diffs[3].Diff).To(o.ContainSubstring("+kind: Service"))
+	})
+
+	t.Run("SecretRedacted", func(t *testing.T) {
+		d := diffs[2].Diff
+		g.Expect(d).ToNot(o.ContainSubstring("c2VjcmV0"))
+		g.Expect(d).ToNot(o.ContainSubstring("Y2hhbmdlZA=="))
+		g.Expect(d).ToNot(o.ContainSubstring("YWRtaW4="))
+		g.Expect(d).To(o.ContainSubstring("+  password: <redacted, changed>"))
+		g.Expect(d).To(o.ContainSubstring(" username: <redacted>"))
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		diffs, err := Manifests(renderedManifest, renderedManifest)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(diffs).To(o.BeEmpty())
+	})
+}
@@ -75,3 +75,13 @@ func TestEngine_Render(t *testing.T) {
 	g.Expect(err).To(o.Succeed())
 	g.Expect(root["catalogURL"]).To(o.Equal(product.Properties["catalogURL"]))
 }
+
+func TestEngine_RenderCapabilities(t *testing.T) {
+	g := o.NewWithT(t)
+
+	tmpl := `{{ .Capabilities.APIVersions.Has "apps/v1" }} ` +
+		`{{ .Capabilities.APIVersions.Has "route.openshift.io/v1" }}`
+	payload, err := NewEngine(nil, tmpl).Render(NewVariables())
+	g.Expect(err).To(o.Succeed())
+	g.Expect(string(payload)).To(o.Equal("true false"))
+}
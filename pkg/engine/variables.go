@@ -7,14 +7,26 @@ import (
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
 	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+	"github.com/redhat-appstudio/tssc-cli/pkg/trust"
 
+	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	// RedHatOperatorsNamespace namespace of the "redhat-operators" CatalogSource.
+	RedHatOperatorsNamespace = "openshift-marketplace"
+	// RedHatOperatorsName name of the Red Hat operators OLM CatalogSource.
+	RedHatOperatorsName = "redhat-operators"
 )
 
 // Variables represents the variables available for "values-template" file.
 type Variables struct {
-	Installer chartutil.Values // .Installer
-	OpenShift chartutil.Values // .OpenShift
+	Installer    chartutil.Values        // .Installer
+	OpenShift    chartutil.Values        // .OpenShift
+	Capabilities *chartutil.Capabilities // .Capabilities
+	Trust        chartutil.Values        // .Trust
 }
 
 // SetInstaller sets the installer configuration.
@@ -43,8 +55,24 @@ func getMinorVersion(version string) (string, error) {
 	return minorVersion, nil
 }
 
-// SetOpenShift sets the OpenShift context variables.
-func (v *Variables) SetOpenShift(ctx context.Context, kube *k8s.Kube) error {
+// topologyValues returns the cluster topology as template variables.
+func topologyValues(t *k8s.ClusterTopology) chartutil.Values {
+	return chartutil.Values{
+		"ControlPlane":   t.ControlPlane,
+		"Infrastructure": t.Infrastructure,
+		"Nodes":          t.Nodes,
+		"SingleNode":     t.SingleNode,
+		"CRC":            t.CRC,
+	}
+}
+
+// SetOpenShift sets the OpenShift context variables, the cluster-wide proxy
+// settings are informed by the trust settings.
+func (v *Variables) SetOpenShift(
+	ctx context.Context,
+	kube *k8s.Kube,
+	t *trust.Trust,
+) error {
 	ingressDomain, err := k8s.GetOpenShiftIngressDomain(ctx, kube)
 	if err != nil {
 		return err
@@ -61,6 +89,26 @@ func (v *Variables) SetOpenShift(ctx context.Context, kube *k8s.Kube) error {
 	if err != nil {
 		return err
 	}
+	// The nodes and the Infrastructure CR are read once, the topology, platform
+	// and architectures are derived from them.
+	nodes, err := k8s.ListNodes(ctx, kube)
+	if err != nil {
+		return err
+	}
+	infrastructure, err := k8s.GetOpenShiftInfrastructure(ctx, kube)
+	if err != nil {
+		return err
+	}
+	topology := k8s.NewClusterTopology(len(nodes), infrastructure, ingressDomain)
+	storageClass, err := k8s.GetDefaultStorageClass(ctx, kube)
+	if err != nil {
+		return err
+	}
+	redHatOperators, err := k8s.CatalogSourceExists(
+		ctx, kube, RedHatOperatorsNamespace, RedHatOperatorsName)
+	if err != nil {
+		return err
+	}
 	v.OpenShift = chartutil.Values{
 		"Ingress": chartutil.Values{
 			"Domain":   ingressDomain,
@@ -68,11 +116,64 @@ func (v *Variables) SetOpenShift(ctx context.Context, kube *k8s.Kube) error {
 		},
 		"Version":      clusterVersion,
 		"MinorVersion": minorVersion,
+		"Topology":     topologyValues(topology),
+		"Platform":     k8s.InfrastructurePlatform(infrastructure),
+		"Proxy": chartutil.Values{
+			"HTTPProxy":  t.HTTPProxy,
+			"HTTPSProxy": t.HTTPSProxy,
+			"NoProxy":    t.NoProxy,
+		},
+		"Architectures":       k8s.NodeArchitectures(nodes),
+		"DefaultStorageClass": storageClass,
+		"OLM": chartutil.Values{
+			"RedHatOperators": redHatOperators,
+		},
 	}
 
 	return nil
 }
 
+// SetCapabilities sets the Helm-style capabilities, the Kubernetes version and
+// the API versions available on the cluster.
+func (v *Variables) SetCapabilities(kube *k8s.Kube) error {
+	dc, err := kube.DiscoveryClient("")
+	if err != nil {
+		return err
+	}
+	kubeVersion, err := dc.ServerVersion()
+	if err != nil {
+		return err
+	}
+	// Orphaned API services don't prevent discovering the remaining APIs.
+	apiVersions, err := action.GetVersionSet(dc)
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return err
+	}
+	v.Capabilities = &chartutil.Capabilities{
+		APIVersions: apiVersions,
+		KubeVersion: chartutil.KubeVersion{
+			Version: kubeVersion.GitVersion,
+			Major:   kubeVersion.Major,
+			Minor:   kubeVersion.Minor,
+		},
+		HelmVersion: chartutil.DefaultCapabilities.HelmVersion,
+	}
+	return nil
+}
+
+// SetTrust sets the proxy settings and the trusted certificates, shared by the
+// installer and the services deployed.
+func (v *Variables) SetTrust(t *trust.Trust) {
+	v.Trust = chartutil.Values{
+		"HTTPProxy":       t.HTTPProxy,
+		"HTTPSProxy":      t.HTTPSProxy,
+		"NoProxy":         t.NoProxy,
+		"ClusterCABundle": t.ClusterCABundle,
+		"CABundle":        t.CABundle,
+		"Bundle":          t.Bundle(),
+	}
+}
+
 // Unstructured returns the variables as "chartutils.Values".
 func (v *Variables) Unstructured() (chartutil.Values, error) {
 	return UnstructuredType(v)
@@ -81,7 +182,9 @@ func (v *Variables) Unstructured() (chartutil.Values, error) {
 // NewVariables instantiates Variables empty.
 func NewVariables() *Variables {
 	return &Variables{
-		Installer: chartutil.Values{},
-		OpenShift: chartutil.Values{},
+		Installer:    chartutil.Values{},
+		OpenShift:    chartutil.Values{},
+		Capabilities: chartutil.DefaultCapabilities.Copy(),
+		Trust:        chartutil.Values{},
 	}
 }
@@ -15,6 +15,7 @@ import (
 
 // Flags represents the global flags for the application.
 type Flags struct {
+	CABundlePath   string        // path to additional trusted CAs, PEM file
 	Debug          bool          // debug mode
 	DryRun         bool          // dry-run mode
 	KubeConfigPath string        // path to the kubeconfig file
@@ -24,6 +25,12 @@ type Flags struct {
 
 // PersistentFlags sets up the global flags.
 func (f *Flags) PersistentFlags(p *pflag.FlagSet) {
+	p.StringVar(
+		&f.CABundlePath,
+		"ca-bundle",
+		f.CABundlePath,
+		"Path to a PEM file with additional trusted certificate authorities",
+	)
 	p.BoolVar(&f.Debug, "debug", f.Debug, "enable debug mode")
 	p.BoolVar(&f.DryRun, "dry-run", f.DryRun, "enable dry-run mode")
 	p.StringVar(
@@ -76,6 +83,7 @@ func NewFlags() *Flags {
 		kubeConfigPath = path.Join(usr.HomeDir, ".kube", "config")
 	}
 	return &Flags{
+		CABundlePath:   "",
 		Debug:          false,
 		DryRun:         false,
 		KubeConfigPath: kubeConfigPath,
@@ -21,7 +21,8 @@ import (
 // web token, thus the oAuth2 workflow uses the (primary) browser to interact with
 // 2FA and other GitHub security measures.
 type GitHubApp struct {
-	logger *slog.Logger // application logger
+	logger     *slog.Logger // application logger
+	httpClient *http.Client // HTTP client for the GitHub API
 
 	gitHubURL     string // GitHub API URL
 	gitHubOrgName string // GitHub organization name
@@ -56,6 +57,12 @@ func (g *GitHubApp) PersistentFlags(cmd *cobra.Command) {
 	}
 }
 
+// SetHTTPClient sets the HTTP client for the GitHub API, carrying the proxy and
+// trusted certificates settings.
+func (g *GitHubApp) SetHTTPClient(c *http.Client) {
+	g.httpClient = c
+}
+
 // Validate validates the GitHub App configuration.
 func (g *GitHubApp) Validate() error {
 	return nil
@@ -75,10 +82,10 @@ func (g *GitHubApp) log() *slog.Logger {
 func (g *GitHubApp) getGitHubClient() (*github.Client, error) {
 	if g.gitHubURL == defaultPublicGitHubURL {
 		g.log().Debug("using public GitHub API")
-		return github.NewClient(nil), nil
+		return github.NewClient(g.httpClient), nil
 	}
 	g.log().Debug("using GitHub Enterprise API")
-	return github.NewEnterpriseClient(g.gitHubURL, "", nil)
+	return github.NewEnterpriseClient(g.gitHubURL, "", g.httpClient)
 }
 
 // oAuth2Workflow starts the oAuth2 workflow to create a new GitHub App. The user
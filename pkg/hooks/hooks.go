@@ -11,7 +11,7 @@ import (
 )
 
 // Hooks represent the hooks that can be executed before and after the Helm Chart
-// installation, it provides the user the ability to customize the process using
+// installation, or removal, it provides the user the ability to customize the process using
 // shell scripts. These scripts can rely on local tools, like "kubectl", "oc" and
 // others, while the Helm Charts are only using Kubernetes resources.
 // Ideally these scripts are temporary measures, and should be replaced by Helm
@@ -85,6 +85,16 @@ func (h *Hooks) PostDeploy(vals map[string]interface{}) error {
 	return h.runHookScript("post-deploy.sh", vals)
 }
 
+// PreDelete executes the "pre-delete.sh" hook script with the given values.
+func (h *Hooks) PreDelete(vals map[string]interface{}) error {
+	return h.runHookScript("pre-delete.sh", vals)
+}
+
+// PostDelete executes the "post-delete.sh" hook script with the given values.
+func (h *Hooks) PostDelete(vals map[string]interface{}) error {
+	return h.runHookScript("post-delete.sh", vals)
+}
+
 // NewHooks instantiates a hooks handler for the given ChartFS and Dependency.
 func NewHooks(
 	dep *resolver.Dependency,
@@ -59,4 +59,26 @@ func TestNewHooks(t *testing.T) {
 		stdout.Reset()
 		stderr.Reset()
 	})
+
+	t.Run("PreDelete", func(t *testing.T) {
+		err := h.PreDelete(vals)
+		g.Expect(err).To(o.Succeed())
+
+		g.Expect(stdout.String()).To(o.ContainSubstring("script runs before"))
+		g.Expect(stdout.String()).
+			To(o.ContainSubstring("# INSTALLER__KEY__NESTED='value'"))
+
+		stdout.Reset()
+		stderr.Reset()
+	})
+
+	t.Run("PostDelete", func(t *testing.T) {
+		err := h.PostDelete(vals)
+		g.Expect(err).To(o.Succeed())
+
+		g.Expect(stdout.String()).To(o.ContainSubstring("after the removal"))
+
+		stdout.Reset()
+		stderr.Reset()
+	})
 }
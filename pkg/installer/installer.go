@@ -2,10 +2,12 @@ package installer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
-	"os"
 
+	"github.com/redhat-appstudio/tssc-cli/pkg/checkpoint"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
 	"github.com/redhat-appstudio/tssc-cli/pkg/deployer"
 	"github.com/redhat-appstudio/tssc-cli/pkg/engine"
@@ -15,8 +17,10 @@ import (
 	"github.com/redhat-appstudio/tssc-cli/pkg/monitor"
 	"github.com/redhat-appstudio/tssc-cli/pkg/printer"
 	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
+	"github.com/redhat-appstudio/tssc-cli/pkg/trust"
 
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
 )
 
 // Installer represents the "helm install" using its APIs, this component deploys
@@ -26,28 +30,47 @@ type Installer struct {
 	flags  *flags.Flags         // global flags
 	kube   *k8s.Kube            // kubernetes client
 	dep    *resolver.Dependency // dependency to install
+	stdout io.Writer            // standard output
+	stderr io.Writer            // standard error
 
 	valuesBytes []byte           // rendered values
 	values      chartutil.Values // helm chart values
+	revision    int              // deployed release revision
+	digest      string           // chart and values digest
 }
 
-// SetValues prepares the values template for the Helm chart installation.
-func (i *Installer) SetValues(
+// NewVariables collects the values template context, the installer
+// configuration, the trust settings and the target cluster facts. The cluster is
+// inspected once, the variables are shared by the dependencies rendered
+// afterwards.
+func NewVariables(
 	ctx context.Context,
+	kube *k8s.Kube,
 	cfg *config.Spec,
-	valuesTmpl string,
-) error {
-	i.logger.Debug("Preparing values template context")
+	t *trust.Trust,
+) (*engine.Variables, error) {
 	variables := engine.NewVariables()
-	err := variables.SetInstaller(cfg)
-	if err != nil {
-		return err
+	if err := variables.SetInstaller(cfg); err != nil {
+		return nil, err
 	}
-	if err = variables.SetOpenShift(ctx, i.kube); err != nil {
-		return err
+	variables.SetTrust(t)
+	if err := variables.SetOpenShift(ctx, kube, t); err != nil {
+		return nil, err
 	}
+	if err := variables.SetCapabilities(kube); err != nil {
+		return nil, err
+	}
+	return variables, nil
+}
 
+// SetValues renders the values template for the Helm chart installation, using
+// the variables collected by NewVariables.
+func (i *Installer) SetValues(
+	variables *engine.Variables,
+	valuesTmpl string,
+) error {
 	i.logger.Debug("Rendering values template")
+	var err error
 	i.valuesBytes, err = engine.NewEngine(i.kube, valuesTmpl).Render(variables)
 	return err
 }
@@ -55,7 +78,7 @@ func (i *Installer) SetValues(
 // PrintRawValues prints the raw values template to the console.
 func (i *Installer) PrintRawValues() {
 	i.logger.Debug("Showing raw results of rendered values template")
-	fmt.Printf("#\n# Values (Raw)\n#\n\n%s\n", i.valuesBytes)
+	fmt.Fprintf(i.stdout, "#\n# Values (Raw)\n#\n\n%s\n", i.valuesBytes)
 }
 
 // RenderValues parses the values template and prepares the Helm chart values.
@@ -70,32 +93,145 @@ func (i *Installer) RenderValues() error {
 	return err
 }
 
-// PrintValues prints the parsed values to the console.
-func (i *Installer) PrintValues() {
-	i.logger.Debug("Showing parsed values")
-	printer.ValuesPrinter("Values", i.values)
+// Values exposes the Helm chart values, available after RenderValues.
+func (i *Installer) Values() chartutil.Values {
+	return i.values
 }
 
-// Install performs the installation of the Helm chart, including the pre and post
-// hooks execution.
-func (i *Installer) Install(ctx context.Context) error {
+// Revision exposes the release revision, available after Install.
+func (i *Installer) Revision() int {
+	return i.revision
+}
+
+// Digest returns the digest of the chart content and rendered values, stored on
+// the Helm release. Available after RenderValues.
+func (i *Installer) Digest() (string, error) {
+	if i.digest != "" {
+		return i.digest, nil
+	}
 	if i.values == nil {
-		return fmt.Errorf("values not set")
+		return "", fmt.Errorf("values not set")
+	}
+	chartDigest, err := checkpoint.ChartDigest(i.dep.Chart())
+	if err != nil {
+		return "", err
+	}
+	valuesDigest, err := checkpoint.ValuesDigest(i.values)
+	if err != nil {
+		return "", err
 	}
+	i.digest = checkpoint.ReleaseDigest(chartDigest, valuesDigest)
+	return i.digest, nil
+}
+
+// Plan determines the action the installation performs, comparing the digest
+// stored on the current release with the chart and rendered values. The current
+// release revision is recorded. Available after RenderValues.
+func (i *Installer) Plan() (deployer.Action, error) {
+	digest, err := i.Digest()
+	if err != nil {
+		return "", err
+	}
+	hc, err := i.helm()
+	if err != nil {
+		return "", err
+	}
+	action, current, err := hc.Plan(digest)
+	if err != nil {
+		return "", err
+	}
+	if current != nil {
+		i.revision = current.Version
+	}
+	return action, nil
+}
 
+// PrintValues prints the parsed values to the console.
+func (i *Installer) PrintValues() {
+	i.logger.Debug("Showing parsed values")
+	printer.ValuesPrinter(i.stdout, "Values", i.values)
+}
+
+// helm instantiates the Helm client for the dependency and namespace.
+func (i *Installer) helm() (*deployer.Helm, error) {
 	i.logger.Debug("Loading Helm client for dependency and namespace")
-	hc, err := deployer.NewHelm(
+	return deployer.NewHelm(
 		i.logger,
 		i.flags,
 		i.kube,
 		i.dep.Namespace(),
 		i.dep.Chart(),
+		i.stdout,
 	)
+}
+
+// DeployedRevision returns the current release revision in the cluster, zero
+// when the release is not found.
+func (i *Installer) DeployedRevision() (int, error) {
+	hc, err := i.helm()
+	if err != nil {
+		return 0, err
+	}
+	rel, err := hc.GetRelease()
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return rel.Version, nil
+}
+
+// Rollback rolls the release back to the informed revision.
+func (i *Installer) Rollback(revision int) error {
+	hc, err := i.helm()
 	if err != nil {
 		return err
 	}
+	if err = hc.Rollback(revision); err != nil {
+		return err
+	}
+	i.logger.Info("Helm chart rolled back!", "revision", revision)
+	return nil
+}
+
+// Manifests renders the Helm chart with the values, without changes in the
+// cluster. Returns the manifest of the deployed release, empty when not deployed,
+// and the rendered manifest.
+func (i *Installer) Manifests() (string, string, error) {
+	if i.values == nil {
+		return "", "", fmt.Errorf("values not set")
+	}
+	hc, err := i.helm()
+	if err != nil {
+		return "", "", err
+	}
+	current, rendered, err := hc.Render(i.values)
+	if err != nil {
+		return "", "", err
+	}
+	if current == nil {
+		return "", rendered.Manifest, nil
+	}
+	return current.Manifest, rendered.Manifest, nil
+}
 
-	hook := hooks.NewHooks(i.dep, os.Stdout, os.Stderr)
+// Install performs the installation of the Helm chart, including the pre and post
+// hooks execution.
+func (i *Installer) Install(ctx context.Context) error {
+	if i.values == nil {
+		return fmt.Errorf("values not set")
+	}
+
+	digest, err := i.Digest()
+	if err != nil {
+		return err
+	}
+	hc, err := i.helm()
+	if err != nil {
+		return err
+	}
+	hook := hooks.NewHooks(i.dep, i.stdout, i.stderr)
 	if !i.flags.DryRun {
 		i.logger.Debug("Running pre-deploy hook script...")
 		if err = hook.PreDeploy(i.values); err != nil {
@@ -108,9 +244,10 @@ func (i *Installer) Install(ctx context.Context) error {
 	// Performing the installation, or upgrade, of the Helm chart dependency,
 	// using the values rendered before hand.
 	i.logger.Debug("Installing the Helm chart")
-	if err = hc.Deploy(i.values); err != nil {
+	if err = hc.Deploy(ctx, i.values); err != nil {
 		return err
 	}
+	i.revision = hc.Revision()
 	// Verifying if the installation was successful, by running the Helm chart
 	// tests interactively.
 	i.logger.Debug("Verifying the Helm chart release")
@@ -125,7 +262,7 @@ func (i *Installer) Install(ctx context.Context) error {
 			return err
 		}
 		i.logger.Debug("Monitoring the Helm chart release...")
-		if err = m.Watch(i.flags.Timeout); err != nil {
+		if err = m.Watch(ctx, i.flags.Timeout); err != nil {
 			return err
 		}
 		i.logger.Debug("Monitoring completed, release is successful!")
@@ -138,21 +275,79 @@ func (i *Installer) Install(ctx context.Context) error {
 		i.logger.Debug("Skipping monitoring and post-deploy hook (dry-run)")
 	}
 
+	// Only the release verified carries the digest, otherwise the next
+	// deployment would consider it unchanged.
+	i.logger.Debug("Recording the release digest")
+	if err = hc.SetDigest(digest); err != nil {
+		return err
+	}
+
 	i.logger.Info("Helm chart installed!")
 	return nil
 }
 
-// NewInstaller instantiates a new installer for the given dependency.
+// Uninstall removes the Helm chart release, running the pre and post delete
+// hooks with the values employed on the release. Releases not found are skipped.
+func (i *Installer) Uninstall() error {
+	hc, err := i.helm()
+	if err != nil {
+		return err
+	}
+
+	rel, err := hc.GetRelease()
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		i.logger.Info("Helm chart release not found, skipping!")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	i.values = rel.Config
+
+	hook := hooks.NewHooks(i.dep, i.stdout, i.stderr)
+	if !i.flags.DryRun {
+		i.logger.Debug("Running pre-delete hook script...")
+		if err = hook.PreDelete(i.values); err != nil {
+			return err
+		}
+	} else {
+		i.logger.Debug("Skipping pre-delete hook script (dry-run)")
+	}
+
+	if err = hc.Uninstall(); err != nil {
+		return err
+	}
+
+	if !i.flags.DryRun {
+		i.logger.Debug("Running post-delete hook script...")
+		if err = hook.PostDelete(i.values); err != nil {
+			return err
+		}
+	} else {
+		i.logger.Debug("Skipping post-delete hook script (dry-run)")
+	}
+
+	i.logger.Info("Helm chart uninstalled!")
+	return nil
+}
+
+// NewInstaller instantiates a new installer for the given dependency. The
+// installation output, including hook scripts, is written on the informed
+// standard output and error writers.
 func NewInstaller(
 	logger *slog.Logger,
 	f *flags.Flags,
 	kube *k8s.Kube,
 	dep *resolver.Dependency,
+	stdout io.Writer,
+	stderr io.Writer,
 ) *Installer {
 	return &Installer{
 		logger: dep.LoggerWith(logger),
 		flags:  f,
 		kube:   kube,
 		dep:    dep,
+		stdout: stdout,
+		stderr: stderr,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
@@ -23,7 +24,8 @@ type GithubIntegration struct {
 	logger *slog.Logger // application logger
 	kube   *k8s.Kube    // kubernetes client
 
-	gitHubApp *githubapp.GitHubApp // github app client
+	gitHubApp  *githubapp.GitHubApp // github app client
+	httpClient *http.Client         // HTTP client for the GitHub API
 
 	force bool // overwrite the existing secret
 
@@ -70,6 +72,13 @@ func (g *GithubIntegration) log() *slog.Logger {
 	)
 }
 
+// SetHTTPClient sets the HTTP client for the GitHub API, carrying the proxy and
+// trusted certificates settings, for the integration and the GitHub App.
+func (g *GithubIntegration) SetHTTPClient(c *http.Client) {
+	g.httpClient = c
+	g.gitHubApp.SetHTTPClient(c)
+}
+
 // Validate checks if the required configuration is set.
 func (g *GithubIntegration) Validate() error {
 	return g.gitHubApp.Validate()
@@ -169,7 +178,7 @@ func (g *GithubIntegration) prepareSecret(
 
 // getCurrentGitHubUser gets the current user name authenticated with github token
 func (g *GithubIntegration) getCurrentGitHubUser(ctx context.Context, ghHost string) (string, error) {
-	gc := github.NewClient(nil).WithAuthToken(g.token)
+	gc := github.NewClient(g.httpClient).WithAuthToken(g.token)
 	if ghHost != "github.com" {
 		ghUrl := fmt.Sprintf("https://%s/api/v3/", ghHost)
 		ghuUrl := fmt.Sprintf("https://%s/api/uploads/", ghHost)
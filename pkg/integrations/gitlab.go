@@ -21,8 +21,9 @@ const defaultPublicGitLabHost = "gitlab.com"
 
 // GitLabIntegration represents the TSSC GitLab integration.
 type GitLabIntegration struct {
-	logger *slog.Logger // application logger
-	kube   *k8s.Kube    // kubernetes client
+	logger     *slog.Logger // application logger
+	kube       *k8s.Kube    // kubernetes client
+	httpClient *http.Client // HTTP client for the GitLab API
 
 	force    bool // overwrite the existing secret
 	insecure bool // Skips tls verification on api calls
@@ -74,6 +75,12 @@ func (g *GitLabIntegration) log() *slog.Logger {
 	)
 }
 
+// SetHTTPClient sets the HTTP client for the GitLab API, carrying the proxy and
+// trusted certificates settings.
+func (g *GitLabIntegration) SetHTTPClient(c *http.Client) {
+	g.httpClient = c
+}
+
 // Validate checks if the required configuration is set.
 func (g *GitLabIntegration) Validate() error {
 	if g.clientId != "" && g.clientSecret == "" {
@@ -132,31 +139,40 @@ func (g *GitLabIntegration) prepareSecret(
 	return k8s.DeleteSecret(ctx, g.kube, g.secretName(cfg))
 }
 
+// httpClientFor returns the HTTP client for the GitLab API, based on the client
+// informed, skipping the TLS verification when insecure.
+func (g *GitLabIntegration) httpClientFor() *http.Client {
+	if !g.insecure {
+		return g.httpClient
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if g.httpClient != nil {
+		if t, ok := g.httpClient.Transport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+	}
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS12,
+	}
+	return &http.Client{Transport: transport}
+}
+
 // getCurrentGitLabUser gets the current user name authenticated with access token
 func (g *GitLabIntegration) getCurrentGitLabUser() (string, error) {
 	url := fmt.Sprintf("https://%s", g.host)
 	logger := g.log()
 
-	cl, err := gitlab.NewClient(g.token, gitlab.WithBaseURL(url))
+	opts := []gitlab.ClientOptionFunc{gitlab.WithBaseURL(url)}
+	if hcl := g.httpClientFor(); hcl != nil {
+		opts = append(opts, gitlab.WithHTTPClient(hcl))
+	}
+	cl, err := gitlab.NewClient(g.token, opts...)
 	if err != nil {
 		logger.Error("Error building gitlab client")
 		return "", err
 	}
 
-	if g.insecure {
-		insecureTransport := &http.Transport{
-		    TLSClientConfig: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12},
-	    }
-
-	    hcl := &http.Client{Transport: insecureTransport}
-
-	    cl, err = gitlab.NewClient(g.token, gitlab.WithBaseURL(url), gitlab.WithHTTPClient(hcl))
-	    if err != nil {
-		    logger.Error("Error building gitlab client")
-		    return "", err
-	    }
-	}
-
 	user, _, err := cl.Users.CurrentUser()
 	if err != nil {
 		logger.Error("Error getting user")
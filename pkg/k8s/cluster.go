@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// DefaultStorageClassAnnotation annotation marking the default StorageClass.
+const DefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// catalogSourceGVR the OLM CatalogSource resource.
+var catalogSourceGVR = schema.GroupVersionResource{
+	Group:    "operators.coreos.com",
+	Version:  "v1alpha1",
+	Resource: "catalogsources",
+}
+
+// ListNodes returns the cluster nodes, empty when the user isn't allowed to list
+// them.
+func ListNodes(ctx context.Context, kube Interface) ([]corev1.Node, error) {
+	coreClient, err := kube.CoreV1ClientSet("")
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := coreClient.Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nodes.Items, nil
+}
+
+// NodeArchitectures returns the distinct CPU architectures of the nodes, sorted.
+func NodeArchitectures(nodes []corev1.Node) []string {
+	architectures := []string{}
+	for _, node := range nodes {
+		arch := node.Status.NodeInfo.Architecture
+		if arch != "" && !slices.Contains(architectures, arch) {
+			architectures = append(architectures, arch)
+		}
+	}
+	slices.Sort(architectures)
+	return architectures
+}
+
+// GetDefaultStorageClass returns the name of the default StorageClass, empty when
+// the cluster doesn't have one, or the user isn't allowed to list them.
+func GetDefaultStorageClass(ctx context.Context, kube Interface) (string, error) {
+	cs, err := kube.ClientSet("")
+	if err != nil {
+		return "", err
+	}
+	list, err := cs.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	for _, sc := range list.Items {
+		if sc.GetAnnotations()[DefaultStorageClassAnnotation] == "true" {
+			return sc.GetName(), nil
+		}
+	}
+	return "", nil
+}
+
+// catalogSourceExists checks if the OLM CatalogSource exists using the informed
+// dynamic client, false when not available.
+func catalogSourceExists(
+	ctx context.Context,
+	dc dynamic.Interface,
+	namespace, name string,
+) (bool, error) {
+	_, err := dc.Resource(catalogSourceGVR).
+		Namespace(namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if IsUnavailable(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CatalogSourceExists checks if the OLM CatalogSource exists, false when OLM is
+// not available on the cluster, or the user isn't allowed to read it.
+func CatalogSourceExists(
+	ctx context.Context,
+	kube Interface,
+	namespace, name string,
+) (bool, error) {
+	dc, err := kube.DynamicClient(namespace)
+	if err != nil {
+		return false, err
+	}
+	return catalogSourceExists(ctx, dc, namespace, name)
+}
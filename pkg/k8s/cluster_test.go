@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNodeArchitectures(t *testing.T) {
+	g := o.NewWithT(t)
+
+	node := func(arch string) corev1.Node {
+		return corev1.Node{Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{Architecture: arch},
+		}}
+	}
+	g.Expect(NodeArchitectures(nil)).To(o.BeEmpty())
+	g.Expect(NodeArchitectures([]corev1.Node{
+		node("arm64"), node("amd64"), node("arm64"), node(""),
+	})).To(o.Equal([]string{"amd64", "arm64"}))
+}
+
+func TestGetDefaultStorageClass(t *testing.T) {
+	g := o.NewWithT(t)
+	ctx := context.Background()
+
+	storageClass := func(name string, isDefault bool) *storagev1.StorageClass {
+		sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if isDefault {
+			sc.Annotations = map[string]string{
+				DefaultStorageClassAnnotation: "true",
+			}
+		}
+		return sc
+	}
+	failList := func(kube *FakeKube, err error) {
+		kube.Fake().PrependReactor("list", "storageclasses",
+			func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, err
+			})
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		name, err := GetDefaultStorageClass(ctx, NewFakeKube(
+			storageClass("standard", false),
+			storageClass("fast", true),
+		))
+		g.Expect(err).To(o.Succeed())
+		g.Expect(name).To(o.Equal("fast"))
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		kube := NewFakeKube(storageClass("fast", true))
+		failList(kube, apierrors.NewForbidden(
+			schema.GroupResource{Group: "storage.k8s.io", Resource: "storageclasses"},
+			"", errors.New("forbidden")))
+		name, err := GetDefaultStorageClass(ctx, kube)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(name).To(o.BeEmpty())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		kube := NewFakeKube()
+		failList(kube, errors.New("connection refused"))
+		_, err := GetDefaultStorageClass(ctx, kube)
+		g.Expect(err).To(o.HaveOccurred())
+	})
+}
+
+func TestCatalogSourceExists(t *testing.T) {
+	g := o.NewWithT(t)
+	ctx := context.Background()
+
+	catalogSource := &unstructured.Unstructured{}
+	catalogSource.SetAPIVersion("operators.coreos.com/v1alpha1")
+	catalogSource.SetKind("CatalogSource")
+	catalogSource.SetNamespace("openshift-marketplace")
+	catalogSource.SetName("redhat-operators")
+
+	failGet := func(err error) *dynamicfake.FakeDynamicClient {
+		dc := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		dc.PrependReactor("get", "catalogsources",
+			func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, err
+			})
+		return dc
+	}
+
+	t.Run("Exists", func(t *testing.T) {
+		dc := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), catalogSource)
+		exists, err := catalogSourceExists(
+			ctx, dc, "openshift-marketplace", "redhat-operators")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(exists).To(o.BeTrue())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		dc := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		exists, err := catalogSourceExists(
+			ctx, dc, "openshift-marketplace", "redhat-operators")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(exists).To(o.BeFalse())
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		dc := failGet(apierrors.NewForbidden(
+			catalogSourceGVR.GroupResource(), "redhat-operators",
+			errors.New("forbidden")))
+		exists, err := catalogSourceExists(
+			ctx, dc, "openshift-marketplace", "redhat-operators")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(exists).To(o.BeFalse())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		dc := failGet(errors.New("connection refused"))
+		_, err := catalogSourceExists(
+			ctx, dc, "openshift-marketplace", "redhat-operators")
+		g.Expect(err).To(o.HaveOccurred())
+	})
+}
@@ -15,14 +15,19 @@ import (
 )
 
 type FakeKube struct {
-	objects []runtime.Object
+	cs *fake.Clientset
 }
 
 var _ Interface = &FakeKube{}
 
 func (f *FakeKube) ClientSet(string) (kubernetes.Interface, error) {
-	cs := fake.NewSimpleClientset(f.objects...)
-	return cs, nil
+	return f.cs, nil
+}
+
+// Fake returns the fake clientset shared by all clients, to register reactors
+// and inspect the actions taken.
+func (f *FakeKube) Fake() *fake.Clientset {
+	return f.cs
 }
 
 func (f *FakeKube) Connected() error {
@@ -105,6 +110,6 @@ func (f *FakeKube) RESTClientGetter(_ string) genericclioptions.RESTClientGetter
 
 func NewFakeKube(objects ...runtime.Object) *FakeKube {
 	return &FakeKube{
-		objects: objects,
+		cs: fake.NewSimpleClientset(objects...),
 	}
 }
@@ -3,8 +3,10 @@ package k8s
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -15,6 +17,7 @@ import (
 	projectv1client "github.com/openshift/client-go/project/clientset/versioned/typed/project/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -203,3 +206,168 @@ func EnsureOpenShiftProject(
 	time.Sleep(5 * time.Second)
 	return nil
 }
+
+const (
+	// OpenShiftConfigNamespace namespace of the cluster-wide configuration.
+	OpenShiftConfigNamespace = "openshift-config"
+	// TrustedCABundleKey the ConfigMap key holding the proxy trusted CA bundle.
+	TrustedCABundleKey = "ca-bundle.crt"
+)
+
+// CRCIngressDomainSuffix the ingress domain suffix of OpenShift Local (CRC).
+const CRCIngressDomainSuffix = "crc.testing"
+
+// ClusterTopology the OpenShift cluster topology, detected from the amount of
+// nodes, the Infrastructure CR and the ingress domain.
+type ClusterTopology struct {
+	// ControlPlane the control plane topology mode, e.g. "SingleReplica".
+	ControlPlane string
+	// Infrastructure the infrastructure topology mode, e.g. "SingleReplica".
+	Infrastructure string
+	// Nodes the amount of cluster nodes, zero when unknown.
+	Nodes int
+	// SingleNode the cluster runs on a single node.
+	SingleNode bool
+	// CRC the cluster is an OpenShift Local (CRC) instance.
+	CRC bool
+}
+
+// NewClusterTopology detects the cluster topology from the amount of nodes, the
+// Infrastructure status and the ingress domain. OpenShift Local clusters are
+// identified by the ingress domain, and are always single-node.
+func NewClusterTopology(
+	nodes int,
+	infrastructure *configv1.InfrastructureStatus,
+	ingressDomain string,
+) *ClusterTopology {
+	t := &ClusterTopology{Nodes: nodes}
+	if infrastructure != nil {
+		t.ControlPlane = string(infrastructure.ControlPlaneTopology)
+		t.Infrastructure = string(infrastructure.InfrastructureTopology)
+	}
+	single := string(configv1.SingleReplicaTopologyMode)
+	t.CRC = strings.HasSuffix(ingressDomain, CRCIngressDomainSuffix)
+	t.SingleNode = t.CRC || nodes == 1 ||
+		t.ControlPlane == single || t.Infrastructure == single
+	return t
+}
+
+// getInfrastructureStatus returns the status of the `cluster` Infrastructure
+// CR, nil when not available.
+func getInfrastructureStatus(
+	ctx context.Context,
+	configClient configv1client.ConfigV1Interface,
+) (*configv1.InfrastructureStatus, error) {
+	infrastructure, err := configClient.
+		Infrastructures().
+		Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		if IsUnavailable(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &infrastructure.Status, nil
+}
+
+// GetOpenShiftInfrastructure returns the status of the `cluster` Infrastructure
+// CR, nil when not found, or the user isn't allowed to read it.
+func GetOpenShiftInfrastructure(
+	ctx context.Context,
+	kube *Kube,
+) (*configv1.InfrastructureStatus, error) {
+	restConfig, err := kube.RESTClientGetter("").ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	configClient, err := configv1client.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return getInfrastructureStatus(ctx, configClient)
+}
+
+// GetOpenShiftTopology detects the OpenShift cluster topology.
+func GetOpenShiftTopology(
+	ctx context.Context,
+	kube *Kube,
+) (*ClusterTopology, error) {
+	nodes, err := ListNodes(ctx, kube)
+	if err != nil {
+		return nil, err
+	}
+	infrastructure, err := GetOpenShiftInfrastructure(ctx, kube)
+	if err != nil {
+		return nil, err
+	}
+	ingressDomain, err := GetOpenShiftIngressDomain(ctx, kube)
+	if err != nil && !errors.Is(err, ErrIngressDomainNotFound) {
+		return nil, err
+	}
+	return NewClusterTopology(len(nodes), infrastructure, ingressDomain), nil
+}
+
+// InfrastructurePlatform returns the infrastructure platform type, e.g. "AWS" or
+// "BareMetal", empty when the Infrastructure status is not available.
+func InfrastructurePlatform(infrastructure *configv1.InfrastructureStatus) string {
+	if infrastructure == nil {
+		return ""
+	}
+	if infrastructure.PlatformStatus != nil &&
+		infrastructure.PlatformStatus.Type != "" {
+		return string(infrastructure.PlatformStatus.Type)
+	}
+	return string(infrastructure.Platform)
+}
+
+// IsUnavailable checks if the error means the resource isn't served by the
+// cluster, or the user isn't allowed to read it.
+func IsUnavailable(err error) bool {
+	return apierrors.IsNotFound(err) ||
+		apierrors.IsForbidden(err) ||
+		meta.IsNoMatchError(err)
+}
+
+// GetOpenShiftProxy returns the `cluster` Proxy CR, carrying the cluster-wide
+// proxy settings on its status, nil when not found.
+func GetOpenShiftProxy(ctx context.Context, kube *Kube) (*configv1.Proxy, error) {
+	restConfig, err := kube.RESTClientGetter("").ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	configClient, err := configv1client.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := configClient.Proxies().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return proxy, nil
+}
+
+// GetOpenShiftTrustedCA returns the PEM encoded certificates trusted by the
+// cluster-wide proxy, the ConfigMap referenced by the Proxy CR "trustedCA" in
+// the "openshift-config" namespace. Empty when not configured.
+func GetOpenShiftTrustedCA(
+	ctx context.Context,
+	kube *Kube,
+	proxy *configv1.Proxy,
+) (string, error) {
+	if proxy == nil || proxy.Spec.TrustedCA.Name == "" {
+		return "", nil
+	}
+	coreClient, err := kube.CoreV1ClientSet(OpenShiftConfigNamespace)
+	if err != nil {
+		return "", err
+	}
+	cm, err := coreClient.ConfigMaps(OpenShiftConfigNamespace).
+		Get(ctx, proxy.Spec.TrustedCA.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return cm.Data[TrustedCABundleKey], nil
+}
@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	configv1client "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func TestNewClusterTopology(t *testing.T) {
+	single := &configv1.InfrastructureStatus{
+		ControlPlaneTopology:   configv1.SingleReplicaTopologyMode,
+		InfrastructureTopology: configv1.SingleReplicaTopologyMode,
+	}
+	ha := &configv1.InfrastructureStatus{
+		ControlPlaneTopology:   configv1.HighlyAvailableTopologyMode,
+		InfrastructureTopology: configv1.HighlyAvailableTopologyMode,
+	}
+
+	t.Run("crc", func(t *testing.T) {
+		g := o.NewWithT(t)
+		topology := NewClusterTopology(1, single, "apps-crc.testing")
+		g.Expect(topology.CRC).To(o.BeTrue())
+		g.Expect(topology.SingleNode).To(o.BeTrue())
+		g.Expect(topology.ControlPlane).To(o.Equal("SingleReplica"))
+	})
+
+	t.Run("single-node", func(t *testing.T) {
+		g := o.NewWithT(t)
+		topology := NewClusterTopology(0, single, "apps.sno.example.com")
+		g.Expect(topology.CRC).To(o.BeFalse())
+		g.Expect(topology.SingleNode).To(o.BeTrue())
+
+		topology = NewClusterTopology(1, nil, "apps.sno.example.com")
+		g.Expect(topology.SingleNode).To(o.BeTrue())
+		g.Expect(topology.ControlPlane).To(o.BeEmpty())
+	})
+
+	t.Run("highly-available", func(t *testing.T) {
+		g := o.NewWithT(t)
+		topology := NewClusterTopology(6, ha, "apps.cluster.example.com")
+		g.Expect(topology.CRC).To(o.BeFalse())
+		g.Expect(topology.SingleNode).To(o.BeFalse())
+		g.Expect(topology.Nodes).To(o.Equal(6))
+	})
+}
+
+func TestIsUnavailable(t *testing.T) {
+	g := o.NewWithT(t)
+
+	proxies := schema.GroupResource{Group: "config.openshift.io", Resource: "proxies"}
+	g.Expect(IsUnavailable(apierrors.NewNotFound(proxies, "cluster"))).
+		To(o.BeTrue())
+	g.Expect(IsUnavailable(apierrors.NewForbidden(
+		proxies, "cluster", errors.New("forbidden")))).To(o.BeTrue())
+	g.Expect(IsUnavailable(&meta.NoKindMatchError{
+		GroupKind: schema.GroupKind{Group: "config.openshift.io", Kind: "Proxy"},
+	})).To(o.BeTrue())
+	g.Expect(IsUnavailable(errors.New("connection refused"))).To(o.BeFalse())
+}
+
+func TestGetInfrastructureStatus(t *testing.T) {
+	g := o.NewWithT(t)
+	ctx := context.Background()
+
+	// newConfigClient returns a client for an API server replying with the
+	// informed status code and object.
+	newConfigClient := func(code int, obj any) configv1client.ConfigV1Interface {
+		srv := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(code)
+				_ = json.NewEncoder(w).Encode(obj)
+			}))
+		t.Cleanup(srv.Close)
+		client, err := configv1client.NewForConfig(&rest.Config{Host: srv.URL})
+		g.Expect(err).To(o.Succeed())
+		return client
+	}
+	infrastructures := configv1.GroupVersion.WithResource("infrastructures").
+		GroupResource()
+
+	t.Run("Found", func(t *testing.T) {
+		status, err := getInfrastructureStatus(ctx, newConfigClient(
+			http.StatusOK, &configv1.Infrastructure{
+				Status: configv1.InfrastructureStatus{
+					ControlPlaneTopology: configv1.SingleReplicaTopologyMode,
+					PlatformStatus: &configv1.PlatformStatus{
+						Type: configv1.AWSPlatformType,
+					},
+				},
+			}))
+		g.Expect(err).To(o.Succeed())
+		g.Expect(status.ControlPlaneTopology).
+			To(o.Equal(configv1.SingleReplicaTopologyMode))
+		g.Expect(InfrastructurePlatform(status)).To(o.Equal("AWS"))
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		forbidden := apierrors.NewForbidden(
+			infrastructures, "cluster", errors.New("forbidden"))
+		status, err := getInfrastructureStatus(ctx, newConfigClient(
+			http.StatusForbidden, forbidden.ErrStatus))
+		g.Expect(err).To(o.Succeed())
+		g.Expect(status).To(o.BeNil())
+		g.Expect(InfrastructurePlatform(status)).To(o.BeEmpty())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		internal := apierrors.NewInternalError(errors.New("etcd unavailable"))
+		_, err := getInfrastructureStatus(ctx, newConfigClient(
+			http.StatusInternalServerError, internal.ErrStatus))
+		g.Expect(err).To(o.HaveOccurred())
+	})
+}
@@ -2,10 +2,14 @@ package mcptools
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
 	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
 
@@ -20,6 +24,7 @@ import (
 // in the cluster.
 type ConfigTools struct {
 	logger *slog.Logger             // application logger
+	cfs    *chartfs.ChartFS         // embedded filesystem
 	cm     *config.ConfigMapManager // cluster config manager
 	kube   *k8s.Kube                // kubernetes client
 
@@ -31,6 +36,8 @@ const (
 	NamespaceArg = "namespace"
 	// SettingsArg settings argument.
 	SettingsArg = "setting"
+	// ProfileArg configuration profile argument.
+	ProfileArg = "profile"
 )
 
 // getHandler similar to "tssc config --get" subcommand it returns a existing TSSC
@@ -86,16 +93,38 @@ func (c *ConfigTools) createHandler(
 	ctx context.Context,
 	ctr mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	// Duplicating the default config for the user input changes.
-	cfg := *c.defaultCfg
+	// Starting from the default config, with the profile layered on it when
+	// informed, for the user input changes.
+	profiles := []string{}
+	if profile, ok := ctr.GetArguments()[ProfileArg].(string); ok && profile != "" {
+		profiles = append(profiles, profile)
+	}
+	paths, err := config.ProfilePaths(c.cfs, profiles...)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.NewConfigFromFiles(c.cfs,
+		append([]string{config.DefaultRelativeConfigPath}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setting the namespace from user input, if provided.
-	if ns, ok := ctr.GetArguments()[NamespaceArg].(string); ok {
-		cfg.Installer.Namespace = ns
+	if ns, ok := ctr.GetArguments()[NamespaceArg].(string); ok && ns != "" {
+		if err = cfg.Set("namespace", strconv.Quote(ns)); err != nil {
+			return nil, err
+		}
 	}
 
-	if settings, ok := ctr.GetArguments()[SettingsArg].(config.Settings); ok {
-		cfg.Installer.Settings = settings
+	if settings, ok := ctr.GetArguments()[SettingsArg].(map[string]any); ok &&
+		len(settings) > 0 {
+		payload, err := json.Marshal(settings)
+		if err != nil {
+			return nil, err
+		}
+		if err = cfg.Set("settings", string(payload)); err != nil {
+			return nil, err
+		}
 	}
 
 	// Ensure the configuration is valid.
@@ -115,7 +144,7 @@ func (c *ConfigTools) createHandler(
 	}
 
 	// Storing the configuration in the cluster.
-	if err := c.cm.Create(ctx, &cfg); err != nil {
+	if err := c.cm.Create(ctx, cfg); err != nil {
 		return nil, err
 	}
 
@@ -135,6 +164,20 @@ TSSC has been successfully configured in namespace %s with settings:
 	), nil
 }
 
+// profilesDescription describes the configuration profiles, for the MCP tools.
+func (c *ConfigTools) profilesDescription() string {
+	profiles, err := config.GetProfiles(c.cfs)
+	if err != nil {
+		return ""
+	}
+	descriptions := []string{}
+	for _, p := range profiles {
+		descriptions = append(descriptions,
+			fmt.Sprintf("%q (%s)", p.Name, p.Description))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
 // Init registers the ConfigTools on the provided MCP server instance.
 func (c *ConfigTools) Init(s *server.MCPServer) {
 	s.AddTools([]server.ServerTool{{
@@ -162,6 +205,14 @@ and other fundamental services will be deployed.`,
 				),
 				mcp.DefaultString(c.defaultCfg.Installer.Namespace),
 			),
+			mcp.WithString(
+				ProfileArg,
+				mcp.Description(fmt.Sprintf(`
+The configuration profile layered on the default configuration, profiles are
+overlays for common scenarios: %s. When empty the default configuration is used.`,
+					c.profilesDescription(),
+				)),
+			),
 			mcp.WithObject(
 				SettingsArg,
 				mcp.Description(`
@@ -177,17 +228,20 @@ settings will be used.
 // NewConfigTools instantiates a new ConfigTools.
 func NewConfigTools(
 	logger *slog.Logger,
+	cfs *chartfs.ChartFS,
 	kube *k8s.Kube,
 	cm *config.ConfigMapManager,
 ) (*ConfigTools, error) {
 	// Loading the default configuration to serve as a reference for MCP tools.
-	defaultCfg, err := config.NewConfigDefault()
+	defaultCfg, err := config.NewConfigFromFile(
+		cfs, config.DefaultRelativeConfigPath)
 	if err != nil {
 		return nil, err
 	}
 
 	c := &ConfigTools{
 		logger:     logger.With("component", "mcp-config-tools"),
+		cfs:        cfs,
 		kube:       kube,
 		cm:         cm,
 		defaultCfg: defaultCfg,
@@ -13,6 +13,6 @@ type Interface interface {
 	Collect(context.Context, *resource.Info) error
 
 	// Watch waits for all monitoring functions to complete, or until the timeout
-	// is reached.
-	Watch(time.Duration) error
+	// is reached, or the context is cancelled.
+	Watch(context.Context, time.Duration) error
 }
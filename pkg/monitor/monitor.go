@@ -58,8 +58,9 @@ func (m *Monitor) Collect(ctx context.Context, r *resource.Info) error {
 }
 
 // Watch waits for all monitoring functions to complete, or until the timeout is
-// reached. Returns error if the queue is not empty after timeout.
-func (m *Monitor) Watch(timeout time.Duration) error {
+// reached, or the context is cancelled. Returns error if the queue is not empty
+// after timeout.
+func (m *Monitor) Watch(ctx context.Context, timeout time.Duration) error {
 	start := time.Now()
 	logger := m.logger.With(
 		"timeout", timeout.String(),
@@ -74,6 +75,9 @@ func (m *Monitor) Watch(timeout time.Duration) error {
 		if time.Since(start) >= timeout {
 			return errors.New("timeout reached")
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		// Run the monitor function, if successful remove it from the queue.
 		if err := m.queue[0](); err == nil {
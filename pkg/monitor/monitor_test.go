@@ -70,7 +70,7 @@ func TestMonitorWatch(t *testing.T) {
 			kube:   k8s.NewFakeKube(),
 			queue:  []monitorQueueFn{noopFn, oneSecondSleepFn},
 		}
-		err := m.Watch(500 * time.Millisecond)
+		err := m.Watch(context.Background(), 500*time.Millisecond)
 		g.Expect(err).To(o.HaveOccurred())
 	})
 
@@ -80,7 +80,19 @@ func TestMonitorWatch(t *testing.T) {
 			kube:   k8s.NewFakeKube(),
 			queue:  []monitorQueueFn{noopFn, noopFn, noopFn},
 		}
-		err := m.Watch(500 * time.Millisecond)
+		err := m.Watch(context.Background(), 500*time.Millisecond)
 		g.Expect(err).ToNot(o.HaveOccurred())
 	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		m := &Monitor{
+			logger: slog.Default(),
+			kube:   k8s.NewFakeKube(),
+			queue:  []monitorQueueFn{oneSecondSleepFn},
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := m.Watch(ctx, 5*time.Second)
+		g.Expect(err).To(o.MatchError(context.Canceled))
+	})
 }
@@ -2,6 +2,7 @@ package printer
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"helm.sh/helm/v3/pkg/release"
@@ -16,43 +17,43 @@ func Disclaimer() {
 }
 
 // HelmReleasePrinter prints the release information.
-func HelmReleasePrinter(rel *release.Release) {
-	fmt.Println("#")
-	fmt.Printf("#       Chart: %s\n", rel.Chart.Metadata.Name)
-	fmt.Printf("#     Version: %s\n", rel.Chart.Metadata.Version)
-	fmt.Printf("#      Status: %s\n", rel.Info.Status.String())
-	fmt.Printf("#   Namespace: %s\n", rel.Namespace)
-	fmt.Printf("#    Revision: %d\n", rel.Version)
-	fmt.Printf("#     Updated: %s\n", rel.Info.LastDeployed.String())
-	fmt.Println("#")
+func HelmReleasePrinter(w io.Writer, rel *release.Release) {
+	fmt.Fprintln(w, "#")
+	fmt.Fprintf(w, "#       Chart: %s\n", rel.Chart.Metadata.Name)
+	fmt.Fprintf(w, "#     Version: %s\n", rel.Chart.Metadata.Version)
+	fmt.Fprintf(w, "#      Status: %s\n", rel.Info.Status.String())
+	fmt.Fprintf(w, "#   Namespace: %s\n", rel.Namespace)
+	fmt.Fprintf(w, "#    Revision: %d\n", rel.Version)
+	fmt.Fprintf(w, "#     Updated: %s\n", rel.Info.LastDeployed.String())
+	fmt.Fprintln(w, "#")
 }
 
 // HelmReleaseNotesPrinter prints the release notes.
-func HelmReleaseNotesPrinter(rel *release.Release) {
+func HelmReleaseNotesPrinter(w io.Writer, rel *release.Release) {
 	if rel.Info.Notes != "" {
-		fmt.Printf("#\n# Notes\n#\n\n")
-		fmt.Println(rel.Info.Notes)
+		fmt.Fprintf(w, "#\n# Notes\n#\n\n")
+		fmt.Fprintln(w, rel.Info.Notes)
 	}
 }
 
 // HelmExtendedReleasePrinter prints the release information, including the
 // manifest and hooks.
-func HelmExtendedReleasePrinter(rel *release.Release) {
-	fmt.Printf("#\n# Manifest\n#\n\n")
-	fmt.Print(rel.Manifest)
+func HelmExtendedReleasePrinter(w io.Writer, rel *release.Release) {
+	fmt.Fprintf(w, "#\n# Manifest\n#\n\n")
+	fmt.Fprint(w, rel.Manifest)
 
 	if len(rel.Hooks) > 0 {
-		fmt.Printf("#\n# Hooks\n#\n")
+		fmt.Fprintf(w, "#\n# Hooks\n#\n")
 		for _, hook := range rel.Hooks {
-			fmt.Printf("---\n%s\n", hook.Manifest)
+			fmt.Fprintf(w, "---\n%s\n", hook.Manifest)
 		}
 	}
 }
 
 // ValuesPrinter prints the values in a map as properties.
-func ValuesPrinter(title string, vals map[string]interface{}) {
-	fmt.Printf("#\n# %s\n#\n\n", title)
+func ValuesPrinter(w io.Writer, title string, vals map[string]interface{}) {
+	fmt.Fprintf(w, "#\n# %s\n#\n\n", title)
 	properties := new(strings.Builder)
 	valuesToProperties(vals, "", properties)
-	printProperties(properties, " * ")
+	printProperties(w, properties, " * ")
 }
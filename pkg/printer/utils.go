@@ -2,6 +2,7 @@ package printer
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -24,11 +25,11 @@ func valuesToProperties(
 	}
 }
 
-func printProperties(sb *strings.Builder, prefix string) {
+func printProperties(w io.Writer, sb *strings.Builder, prefix string) {
 	lines := strings.Split(sb.String(), "\n")
 	for i, line := range lines {
 		if i < len(lines)-1 {
-			fmt.Printf("%s%s\n", prefix, line)
+			fmt.Fprintf(w, "%s%s\n", prefix, line)
 		}
 	}
 }
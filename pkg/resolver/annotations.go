@@ -11,9 +11,14 @@ var (
 	ProductNameAnnotation = fmt.Sprintf("%s/product-name", constants.RepoURI)
 
 	// DependsOnAnnotation defines the list of Helm chart names a chart requires
-	// to be installed before it can be installed.
+	// to be installed before it can be installed. Each chart name may carry a
+	// semantic version constraint, as in "tssc-tas@^1.2".
 	DependsOnAnnotation = fmt.Sprintf("%s/depends-on", constants.RepoURI)
 
+	// EnabledWhenAnnotation defines a condition over the installer settings and
+	// products, when false the chart is skipped from the topology.
+	EnabledWhenAnnotation = fmt.Sprintf("%s/enabled-when", constants.RepoURI)
+
 	// UseProductNamespaceAnnotation defines the Helm chart should use the same
 	// namespace than the referred product name.
 	UseProductNamespaceAnnotation = fmt.Sprintf(
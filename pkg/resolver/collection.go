@@ -9,7 +9,8 @@ import (
 )
 
 // Collection represents a collection of dependencies the Resolver can utilize.
-// The collection is concise, all dependencies and product names must be unique.
+// The collection is concise, all dependencies must be unique, while a product
+// may be composed by several dependencies.
 type Collection struct {
 	dependencies map[string]*Dependency // dependencies by name
 }
@@ -51,35 +52,50 @@ func (c *Collection) Walk(fn CollectionWalkFn) error {
 	return nil
 }
 
-// GetProductDependency returns the dependency associated with the informed
-// product. Returns error when no dependency is found.
-func (c *Collection) GetProductDependency(product string) (*Dependency, error) {
-	var productDependency *Dependency
+// GetProductDependencies returns the dependencies associated with the informed
+// product, a product may be composed by several charts. The dependencies are
+// ordered by the "depends-on" relationship between them, and then by name.
+// Returns error when no dependency is found.
+func (c *Collection) GetProductDependencies(product string) ([]*Dependency, error) {
+	pending := []*Dependency{}
 	_ = c.Walk(func(_ string, d Dependency) error {
-		// Already found the product dependency, no need to continue.
-		if productDependency != nil {
-			return nil
-		}
 		// Check if the dependency is associated with the product.
 		if name := d.ProductName(); name != "" && name == product {
-			productDependency = &d
+			pending = append(pending, &d)
 		}
 		return nil
 	})
-	if productDependency == nil {
+	if len(pending) == 0 {
 		return nil, fmt.Errorf("%w: for product %s",
 			ErrDependencyNotFound, product)
 	}
-	return productDependency, nil
+
+	// Ordering the product dependencies, a dependency comes after the product
+	// dependencies it depends on. On circular dependencies the remaining are
+	// kept in name order, the resolver reports the cycle.
+	productDependencies := make([]*Dependency, 0, len(pending))
+	for len(pending) > 0 {
+		next := slices.IndexFunc(pending, func(d *Dependency) bool {
+			return !slices.ContainsFunc(d.DependsOn(), func(name string) bool {
+				return slices.ContainsFunc(pending, func(p *Dependency) bool {
+					return p.Name() == name
+				})
+			})
+		})
+		if next < 0 {
+			next = 0
+		}
+		productDependencies = append(productDependencies, pending[next])
+		pending = slices.Delete(pending, next, next+1)
+	}
+	return productDependencies, nil
 }
 
 // NewCollection creates a new Collection from the given charts. It returns an
-// error if there are duplicate charts and product names.
+// error if there are duplicate charts.
 func NewCollection(charts []chart.Chart) (*Collection, error) {
 	// Creating a new collection without dependencies.
 	c := &Collection{dependencies: map[string]*Dependency{}}
-	// Stores the product names found in the slice of Helm charts.
-	productNames := []string{}
 	// Populating the collection with dependencies.
 	for _, hc := range charts {
 		// Creating a new dependency.
@@ -90,14 +106,19 @@ func NewCollection(charts []chart.Chart) (*Collection, error) {
 				ErrInvalidCollection, d.Name(),
 			)
 		}
-		// Product names must be unique.
-		if name := d.ProductName(); name != "" {
-			if slices.Contains(productNames, name) {
-				return nil, fmt.Errorf("%w: duplicate product name: %s",
-					ErrInvalidCollection, name)
+		// Version constraints on required charts must be valid.
+		for _, r := range d.Requirements() {
+			if _, err := r.Constraints(); err != nil {
+				return nil, fmt.Errorf("%w: chart %s: invalid constraint %q: %s",
+					ErrInvalidCollection, d.Name(), r.String(), err)
+			}
+		}
+		// The condition to include the chart must be valid.
+		if condition := d.EnabledWhen(); condition != "" {
+			if _, err := parseCondition(condition, conditionFuncs(nil)); err != nil {
+				return nil, fmt.Errorf("%w: chart %s: invalid condition %q: %s",
+					ErrInvalidCollection, d.Name(), condition, err)
 			}
-			// Caching product names.
-			productNames = append(productNames, name)
 		}
 		// Insert the dependency into the collection.
 		c.dependencies[d.Name()] = d
@@ -5,6 +5,8 @@ import (
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 
+	"helm.sh/helm/v3/pkg/chart"
+
 	o "github.com/onsi/gomega"
 )
 
@@ -20,4 +22,31 @@ func TestNewCollection(t *testing.T) {
 	c, err := NewCollection(charts)
 	g.Expect(err).To(o.Succeed())
 	g.Expect(c).NotTo(o.BeNil())
+
+	t.Run("GetProductDependencies", func(t *testing.T) {
+		deps, err := c.GetProductDependencies("Developer Hub")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(deps).To(o.HaveLen(1))
+		g.Expect(deps[0].Name()).To(o.Equal("tssc-dh"))
+
+		_, err = c.GetProductDependencies("Unknown")
+		g.Expect(err).To(o.MatchError(ErrDependencyNotFound))
+	})
+
+	t.Run("MultipleChartsPerProduct", func(t *testing.T) {
+		c, err := NewCollection([]chart.Chart{
+			newTestChart("addon", "1.0.0", "Product", "base"),
+			newTestChart("base", "1.0.0", "Product", ""),
+			newTestChart("extra", "1.0.0", "Product", ""),
+		})
+		g.Expect(err).To(o.Succeed())
+
+		deps, err := c.GetProductDependencies("Product")
+		g.Expect(err).To(o.Succeed())
+		names := []string{}
+		for _, d := range deps {
+			names = append(names, d.Name())
+		}
+		g.Expect(names).To(o.Equal([]string{"base", "addon", "extra"}))
+	})
 }
@@ -0,0 +1,78 @@
+package resolver
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"text/template"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+// ErrInvalidCondition the "enabled-when" condition can't be evaluated.
+var ErrInvalidCondition = fmt.Errorf("invalid enabled-when condition")
+
+// conditionFuncs returns the functions exposing the installer configuration to
+// "enabled-when" conditions: "settings" returns the installer settings, and
+// "products" returns the products by key name, for instance:
+//
+//	not settings.crc
+//	eq products.Developer_Hub.properties.authProvider "github"
+func conditionFuncs(cfg *config.Config) template.FuncMap {
+	settings := map[string]interface{}{}
+	products := map[string]interface{}{}
+	if cfg != nil {
+		maps.Copy(settings, cfg.Installer.Settings)
+		for _, p := range cfg.Installer.Products {
+			products[p.KeyName()] = map[string]interface{}{
+				"name":       p.Name,
+				"enabled":    p.Enabled,
+				"namespace":  p.GetNamespace(),
+				"properties": p.Properties,
+			}
+		}
+	}
+	funcMap := sprig.TxtFuncMap()
+	funcMap["settings"] = func() map[string]interface{} { return settings }
+	funcMap["products"] = func() map[string]interface{} { return products }
+	return funcMap
+}
+
+// parseCondition parses the "enabled-when" condition, a Go template pipeline,
+// as used on "values.yaml.tpl".
+func parseCondition(
+	condition string,
+	funcMap template.FuncMap,
+) (*template.Template, error) {
+	return template.New(EnabledWhenAnnotation).
+		Funcs(funcMap).
+		Option("missingkey=zero").
+		Parse(fmt.Sprintf("{{ if %s }}true{{ end }}", condition))
+}
+
+// isEnabled evaluates the dependency "enabled-when" condition against the
+// installer configuration, dependencies without condition are always enabled.
+func (r *Resolver) isEnabled(d *Dependency) (bool, error) {
+	condition := d.EnabledWhen()
+	if condition == "" {
+		return true, nil
+	}
+	tmpl, err := parseCondition(condition, conditionFuncs(r.cfg))
+	if err != nil {
+		return false, fmt.Errorf("%w: chart %s: %s",
+			ErrInvalidCondition, d.Name(), err)
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, nil); err != nil {
+		return false, fmt.Errorf("%w: chart %s: %s",
+			ErrInvalidCondition, d.Name(), err)
+	}
+	if buf.String() == "true" {
+		return true, nil
+	}
+	// Recording the skipped chart, to be shown on the topology.
+	r.skipped[d.Name()] = condition
+	return false, nil
+}
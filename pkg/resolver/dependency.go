@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"helm.sh/helm/v3/pkg/chart"
 )
 
@@ -46,8 +47,44 @@ func (d *Dependency) SetNamespace(namespace string) {
 	d.namespace = namespace
 }
 
-// DependsOn returns a slice of dependencies names from the chart's annotation.
-func (d *Dependency) DependsOn() []string {
+// Requirement represents a chart required by the dependency, with an optional
+// semantic version constraint the required chart version must satisfy.
+type Requirement struct {
+	// Name the required Helm chart name.
+	Name string
+	// Constraint the semantic version constraint, empty when any version is
+	// accepted. For instance: "^1.2" or ">= 1.3".
+	Constraint string
+}
+
+// RequirementConstraintSeparator separates the chart name from the version
+// constraint on the "depends-on" annotation, as in "tssc-tas@^1.2".
+const RequirementConstraintSeparator = "@"
+
+// String returns the requirement as informed on the annotation.
+func (r Requirement) String() string {
+	if r.Constraint == "" {
+		return r.Name
+	}
+	return r.Name + RequirementConstraintSeparator + r.Constraint
+}
+
+// Constraints parses the version constraint, nil when no constraint is set.
+func (r Requirement) Constraints() (*semver.Constraints, error) {
+	if r.Constraint == "" {
+		return nil, nil
+	}
+	return semver.NewConstraint(r.Constraint)
+}
+
+// Version returns the Helm chart version.
+func (d *Dependency) Version() string {
+	return d.chart.Metadata.Version
+}
+
+// Requirements returns the charts required on the chart's annotation, and their
+// optional version constraints.
+func (d *Dependency) Requirements() []Requirement {
 	dependsOn, exists := d.chart.Metadata.Annotations[DependsOnAnnotation]
 	if !exists {
 		return nil
@@ -57,15 +94,33 @@ func (d *Dependency) DependsOn() []string {
 		return nil
 	}
 	parts := strings.Split(dependsOn, ",")
-	out := make([]string, 0, len(parts))
+	out := make([]Requirement, 0, len(parts))
 	for _, p := range parts {
-		if name := strings.TrimSpace(p); name != "" {
-			out = append(out, name)
+		name, constraint, _ := strings.Cut(
+			strings.TrimSpace(p), RequirementConstraintSeparator)
+		if name = strings.TrimSpace(name); name != "" {
+			out = append(out, Requirement{
+				Name:       name,
+				Constraint: strings.TrimSpace(constraint),
+			})
 		}
 	}
 	return out
 }
 
+// DependsOn returns a slice of dependencies names from the chart's annotation.
+func (d *Dependency) DependsOn() []string {
+	requirements := d.Requirements()
+	if requirements == nil {
+		return nil
+	}
+	out := make([]string, 0, len(requirements))
+	for _, r := range requirements {
+		out = append(out, r.Name)
+	}
+	return out
+}
+
 // ProductName returns the product name from the chart annotations.
 func (d *Dependency) ProductName() string {
 	name, exists := d.chart.Metadata.Annotations[ProductNameAnnotation]
@@ -75,6 +130,12 @@ func (d *Dependency) ProductName() string {
 	return ""
 }
 
+// EnabledWhen returns the condition to include the chart in the topology, from
+// the chart annotations.
+func (d *Dependency) EnabledWhen() string {
+	return strings.TrimSpace(d.chart.Metadata.Annotations[EnabledWhenAnnotation])
+}
+
 // UseProductNamespace returns the product namespace from the chart annotations.
 func (d *Dependency) UseProductNamespace() string {
 	ns, exists := d.chart.Metadata.Annotations[UseProductNamespaceAnnotation]
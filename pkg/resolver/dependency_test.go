@@ -37,6 +37,21 @@ func TestNewDependency(t *testing.T) {
 		g.Expect(dependsOn[0]).To(o.Equal("tssc-openshift"))
 	})
 
+	t.Run("Requirements", func(t *testing.T) {
+		hc := newTestChart("tssc-test", "1.0.0", "",
+			"tssc-openshift, tssc-tas@^1.2,tssc-iam@>= 1.3 < 2")
+		d := NewDependency(&hc)
+		g.Expect(d.Version()).To(o.Equal("1.0.0"))
+		g.Expect(d.Requirements()).To(o.Equal([]Requirement{
+			{Name: "tssc-openshift"},
+			{Name: "tssc-tas", Constraint: "^1.2"},
+			{Name: "tssc-iam", Constraint: ">= 1.3 < 2"},
+		}))
+		g.Expect(d.DependsOn()).To(o.Equal([]string{
+			"tssc-openshift", "tssc-tas", "tssc-iam",
+		}))
+	})
+
 	t.Run("ProductName", func(t *testing.T) {
 		g.Expect(d.ProductName()).To(o.Equal("Developer Hub"))
 	})
@@ -0,0 +1,178 @@
+package resolver
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// reason describes why a chart is part of the topology, only one of the fields
+// is set per reason.
+type reason struct {
+	product    string // the chart belongs to this enabled product
+	requiredBy string // this chart in the topology depends on the chart
+	requires   string // the chart depends on this chart in the topology
+}
+
+// Chain describes how an enabled product puts a chart in the topology, going
+// through the "depends-on" edges between the product chart and the chart.
+type Chain struct {
+	// Product the enabled product name.
+	Product string
+	// Steps describes each edge, from the product chart to the explained chart.
+	Steps []string
+}
+
+// Explanation describes why a chart is part of the resolved topology.
+type Explanation struct {
+	// Name the explained chart name.
+	Name string
+	// Namespace the explained chart target namespace.
+	Namespace string
+	// Chains the shortest chain for each enabled product requiring the chart.
+	Chains []Chain
+	// Toggles the products that, once disabled, remove the chart from the
+	// topology.
+	Toggles []string
+	// AllToggles when true, all products in Toggles must be disabled together,
+	// otherwise disabling any one of them is enough.
+	AllToggles bool
+}
+
+// ErrChartNotInTopology the chart is not part of the resolved topology.
+var ErrChartNotInTopology = fmt.Errorf("chart is not part of the topology")
+
+// addReason records the reason for the chart to be part of the topology.
+func (r *Resolver) addReason(name string, rs reason) {
+	if !slices.Contains(r.reasons[name], rs) {
+		r.reasons[name] = append(r.reasons[name], rs)
+	}
+}
+
+// chains walks the recorded reasons backwards, from the informed chart to the
+// enabled products, returning the shortest chain for each product.
+func (r *Resolver) chains(name string) []Chain {
+	// node a chart visited while walking the reasons, carrying the steps from
+	// the chart back to the explained chart.
+	type node struct {
+		name  string
+		steps []string
+	}
+
+	chains := []Chain{}
+	visited := map[string]bool{name: true}
+	queue := []node{{name: name, steps: []string{}}}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, rs := range r.reasons[n.name] {
+			var next, step string
+			switch {
+			case rs.product != "":
+				if slices.ContainsFunc(chains, func(c Chain) bool {
+					return c.Product == rs.product
+				}) {
+					continue
+				}
+				chains = append(chains, Chain{
+					Product: rs.product,
+					Steps: append([]string{fmt.Sprintf(
+						"product %q is enabled, chart %q", rs.product, n.name,
+					)}, n.steps...),
+				})
+				continue
+			case rs.requiredBy != "":
+				next = rs.requiredBy
+				step = fmt.Sprintf("%q depends-on %q", next, n.name)
+			case rs.requires != "":
+				next = rs.requires
+				step = fmt.Sprintf(
+					"%q depends-on %q, added alongside it", n.name, next)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, node{
+				name:  next,
+				steps: append([]string{step}, n.steps...),
+			})
+		}
+	}
+	return chains
+}
+
+// resolvesWithout resolves the topology again with the informed products
+// disabled, returns true when the chart is no longer part of the topology.
+func (r *Resolver) resolvesWithout(name string, products ...string) bool {
+	t, err := r.ResolveWithout(products...)
+	if err != nil {
+		return false
+	}
+	return !t.Contains(name)
+}
+
+// Explain describes why the chart is part of the resolved topology, the chains
+// of "depends-on" edges leading from the enabled products to the chart, and the
+// product toggles that would remove it. The topology must be resolved first.
+func (r *Resolver) Explain(name string) (*Explanation, error) {
+	d, err := r.topology.GetDependency(name)
+	if err != nil {
+		if condition, skipped := r.skipped[name]; skipped {
+			return nil, fmt.Errorf("%w: %q, skipped by enabled-when %q",
+				ErrChartNotInTopology, name, condition)
+		}
+		return nil, fmt.Errorf("%w: %q", ErrChartNotInTopology, name)
+	}
+	e := &Explanation{
+		Name:      d.Name(),
+		Namespace: d.Namespace(),
+		Chains:    r.chains(name),
+		Toggles:   []string{},
+	}
+
+	products := []string{}
+	for _, c := range e.Chains {
+		products = append(products, c.Product)
+	}
+	// Looking for single products that remove the chart when disabled, otherwise
+	// all products in the chains must be disabled together.
+	for _, product := range products {
+		if r.resolvesWithout(name, product) {
+			e.Toggles = append(e.Toggles, product)
+		}
+	}
+	if len(e.Toggles) == 0 && r.resolvesWithout(name, products...) {
+		e.Toggles = products
+		e.AllToggles = len(products) > 1
+	}
+	return e, nil
+}
+
+// Print prints the explanation to the writer.
+func (e *Explanation) Print(w io.Writer) {
+	fmt.Fprintf(w, "Chart %q (namespace %q) is part of the topology:\n",
+		e.Name, e.Namespace)
+	for i, c := range e.Chains {
+		fmt.Fprintf(w, "\n  %d. %s\n", i+1, c.Steps[0])
+		for _, step := range c.Steps[1:] {
+			fmt.Fprintf(w, "     -> %s\n", step)
+		}
+	}
+
+	fmt.Fprintln(w)
+	switch {
+	case len(e.Toggles) == 0:
+		fmt.Fprintln(w, "The chart can't be removed by disabling products.")
+		return
+	case e.AllToggles:
+		fmt.Fprintln(w, "To remove it, disable all of the following products:")
+	case len(e.Toggles) == 1:
+		fmt.Fprintln(w, "To remove it, disable the product:")
+	default:
+		fmt.Fprintln(w, "To remove it, disable any of the following products:")
+	}
+	for _, product := range e.Toggles {
+		fmt.Fprintf(w, "  tssc.products[%s].enabled: false\n", product)
+	}
+}
@@ -0,0 +1,111 @@
+package resolver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+)
+
+// Problem represents a consistency issue found on the charts annotations or the
+// installer configuration.
+type Problem struct {
+	// Chart the Helm chart name, empty when the problem is on the configuration.
+	Chart string
+	// Message describes the problem.
+	Message string
+}
+
+// String returns the problem as a single line.
+func (p Problem) String() string {
+	if p.Chart == "" {
+		return fmt.Sprintf("config: %s", p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.Chart, p.Message)
+}
+
+// Lint inspects the collection of charts against the installer configuration,
+// offline, and returns the problems found. It reports:
+//
+//   - "depends-on" entries referring to charts not in the collection;
+//   - "product-name" values missing from the configuration;
+//   - "use-product-namespace" pointing at unknown products;
+//   - configured products without a chart in the collection;
+//   - orphan charts, no chart depends on them and they depend on no chart, thus
+//     never part of the topology;
+//   - the resolution errors, when the previous checks succeed;
+//   - charts in the resolved topology using the namespace of a disabled product.
+func Lint(cfg *config.Config, c *Collection) []Problem {
+	problems := []Problem{}
+	add := func(chart, format string, a ...any) {
+		problems = append(problems, Problem{
+			Chart:   chart,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	// Charts required by at least another chart.
+	required := map[string]bool{}
+	_ = c.Walk(func(name string, d Dependency) error {
+		for _, dependsOn := range d.DependsOn() {
+			required[dependsOn] = true
+			if _, err := c.Get(dependsOn); err != nil {
+				add(name, "depends-on unknown chart %q", dependsOn)
+			}
+		}
+		if product := d.ProductName(); product != "" {
+			if _, err := cfg.GetProduct(product); err != nil {
+				add(name, "product-name %q is not in the configuration", product)
+			}
+		}
+		if product := d.UseProductNamespace(); product != "" {
+			if _, err := cfg.GetProduct(product); err != nil {
+				add(name, "use-product-namespace %q is not in the configuration",
+					product)
+			}
+		}
+		return nil
+	})
+
+	// Configured products must have a chart in the collection.
+	for _, product := range cfg.Installer.Products {
+		if _, err := c.GetProductDependencies(product.Name); err != nil {
+			add("", "product %q has no chart in the collection", product.Name)
+		}
+	}
+
+	// Regular charts must be reachable, either required by another chart, or
+	// depending on charts to be added alongside them.
+	_ = c.Walk(func(name string, d Dependency) error {
+		if d.ProductName() == "" && !required[name] && len(d.DependsOn()) == 0 {
+			add(name, "orphan chart, nothing depends on it")
+		}
+		return nil
+	})
+
+	// Resolving the topology, detects circular dependencies and unsatisfied
+	// version constraints. Only the charts deployed are inspected for disabled
+	// product namespaces.
+	if len(problems) == 0 {
+		topology := NewTopology()
+		if err := NewResolver(cfg, c, topology).Resolve(); err != nil {
+			add("", "%s", err)
+		}
+		for _, d := range topology.Dependencies() {
+			product := d.UseProductNamespace()
+			if product == "" {
+				continue
+			}
+			if spec, err := cfg.GetProduct(product); err == nil && !spec.Enabled {
+				add(d.Name(), "use-product-namespace %q is a disabled product",
+					product)
+			}
+		}
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		return strings.Compare(a.Chart, b.Chart)
+	})
+	return problems
+}
@@ -0,0 +1,125 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+
+	"helm.sh/helm/v3/pkg/chart"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestLint(t *testing.T) {
+	g := o.NewWithT(t)
+
+	t.Run("Installer", func(t *testing.T) {
+		cfs, err := chartfs.NewChartFS("../../installer")
+		g.Expect(err).To(o.Succeed())
+		cfg, err := config.NewConfigFromFile(cfs, "config.yaml")
+		g.Expect(err).To(o.Succeed())
+		charts, err := cfs.GetAllCharts()
+		g.Expect(err).To(o.Succeed())
+		c, err := NewCollection(charts)
+		g.Expect(err).To(o.Succeed())
+
+		g.Expect(Lint(cfg, c)).To(o.BeEmpty())
+	})
+
+	t.Run("Profiles", func(t *testing.T) {
+		cfs, err := chartfs.NewChartFS("../../installer")
+		g.Expect(err).To(o.Succeed())
+		charts, err := cfs.GetAllCharts()
+		g.Expect(err).To(o.Succeed())
+		c, err := NewCollection(charts)
+		g.Expect(err).To(o.Succeed())
+
+		// The shipped profiles disable products, the charts requiring them are
+		// not deployed, thus not reported.
+		profiles, err := config.GetProfiles(cfs)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(profiles).ToNot(o.BeEmpty())
+		for _, profile := range profiles {
+			paths, err := config.ProfilePaths(cfs, profile.Name)
+			g.Expect(err).To(o.Succeed())
+			cfg, err := config.NewConfigFromFiles(
+				cfs, append([]string{"config.yaml"}, paths...)...)
+			g.Expect(err).To(o.Succeed())
+			g.Expect(Lint(cfg, c)).To(o.BeEmpty(), profile.Name)
+		}
+	})
+
+	t.Run("DisabledProductNamespace", func(t *testing.T) {
+		cfg, err := config.NewConfigFromBytes([]byte(`
+tssc:
+  namespace: tssc
+  settings: {}
+  products:
+    - name: Product
+      enabled: true
+      namespace: product
+    - name: Disabled
+      enabled: false
+      namespace: disabled
+`))
+		g.Expect(err).To(o.Succeed())
+
+		namespaced := newTestChart("namespaced", "1.0.0", "", "product")
+		namespaced.Metadata.Annotations[UseProductNamespaceAnnotation] = "Disabled"
+		test := newTestChart("disabled-test", "1.0.0", "", "disabled")
+		test.Metadata.Annotations[UseProductNamespaceAnnotation] = "Disabled"
+		c, err := NewCollection([]chart.Chart{
+			newTestChart("product", "1.0.0", "Product", ""),
+			newTestChart("disabled", "1.0.0", "Disabled", ""),
+			namespaced,
+			test,
+		})
+		g.Expect(err).To(o.Succeed())
+
+		// Only the chart deployed alongside the enabled product is reported.
+		problems := []string{}
+		for _, p := range Lint(cfg, c) {
+			problems = append(problems, p.String())
+		}
+		g.Expect(problems).To(o.Equal([]string{
+			`namespaced: use-product-namespace "Disabled" is a disabled product`,
+		}))
+	})
+
+	t.Run("Problems", func(t *testing.T) {
+		cfg, err := config.NewConfigFromBytes([]byte(`
+tssc:
+  namespace: tssc
+  settings: {}
+  products:
+    - name: Product
+      enabled: true
+      namespace: product
+    - name: Disabled
+      enabled: false
+    - name: Missing
+      enabled: false
+`))
+		g.Expect(err).To(o.Succeed())
+
+		c, err := NewCollection([]chart.Chart{
+			newTestChart("product", "1.0.0", "Product", "typo"),
+			newTestChart("disabled", "1.0.0", "Disabled", ""),
+			newTestChart("unknown", "1.0.0", "Unknown", ""),
+			newTestChart("orphan", "1.0.0", "", ""),
+		})
+		g.Expect(err).To(o.Succeed())
+
+		problems := []string{}
+		for _, p := range Lint(cfg, c) {
+			problems = append(problems, p.String())
+		}
+		g.Expect(problems).To(o.Equal([]string{
+			`config: product "Missing" has no chart in the collection`,
+			`orphan: orphan chart, nothing depends on it`,
+			`product: depends-on unknown chart "typo"`,
+			`unknown: product-name "Unknown" is not in the configuration`,
+		}))
+	})
+}
@@ -0,0 +1,185 @@
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat represents the supported formats to print the resolved topology.
+type OutputFormat string
+
+const (
+	// TableOutput formats the topology as a table, the default.
+	TableOutput OutputFormat = "table"
+	// JSONOutput formats the topology as JSON.
+	JSONOutput OutputFormat = "json"
+	// YAMLOutput formats the topology as YAML.
+	YAMLOutput OutputFormat = "yaml"
+	// DotOutput formats the topology as a Graphviz "dot" directed graph.
+	DotOutput OutputFormat = "dot"
+	// MermaidOutput formats the topology as a Mermaid flowchart.
+	MermaidOutput OutputFormat = "mermaid"
+)
+
+// OutputFormats lists all supported output formats.
+var OutputFormats = []OutputFormat{
+	TableOutput,
+	JSONOutput,
+	YAMLOutput,
+	DotOutput,
+	MermaidOutput,
+}
+
+// ErrUnsupportedOutputFormat the informed output format is not supported.
+var ErrUnsupportedOutputFormat = fmt.Errorf("unsupported output format")
+
+// DependencyReport describes a resolved dependency in the topology.
+type DependencyReport struct {
+	// Index the resolved deployment order, starting from one.
+	Index int `json:"index" yaml:"index"`
+	// Name the Helm chart name.
+	Name string `json:"name" yaml:"name"`
+	// Namespace the target namespace.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Product the product name associated with the Helm chart, if any.
+	Product string `json:"product,omitempty" yaml:"product,omitempty"`
+	// DependsOn the Helm charts this dependency requires.
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+}
+
+// SkippedReport describes a chart skipped by its "enabled-when" condition.
+type SkippedReport struct {
+	// Name the Helm chart name.
+	Name string `json:"name" yaml:"name"`
+	// EnabledWhen the condition not met.
+	EnabledWhen string `json:"enabledWhen" yaml:"enabledWhen"`
+}
+
+// TopologyReport describes the resolved topology, it's the structure printed
+// for machine-readable output formats.
+type TopologyReport struct {
+	// Dependencies the resolved dependencies in deployment order.
+	Dependencies []DependencyReport `json:"dependencies" yaml:"dependencies"`
+	// Skipped the charts skipped by their "enabled-when" condition.
+	Skipped []SkippedReport `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+}
+
+// nodeIDRegexp matches the characters not allowed on graph node identifiers.
+var nodeIDRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// nodeID returns a graph node identifier for the dependency name.
+func nodeID(name string) string {
+	return nodeIDRegexp.ReplaceAllString(name, "_")
+}
+
+// Report describes the resolved topology.
+func (r *Resolver) Report() *TopologyReport {
+	report := &TopologyReport{Dependencies: []DependencyReport{}}
+	for i, d := range r.topology.Dependencies() {
+		report.Dependencies = append(report.Dependencies, DependencyReport{
+			Index:     i + 1,
+			Name:      d.Name(),
+			Namespace: d.Namespace(),
+			Product:   d.ProductName(),
+			DependsOn: d.DependsOn(),
+		})
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.skipped)) {
+		report.Skipped = append(report.Skipped, SkippedReport{
+			Name:        name,
+			EnabledWhen: r.skipped[name],
+		})
+	}
+	return report
+}
+
+// PrintJSON prints the resolved topology to the writer formatted as JSON.
+func (r *Resolver) PrintJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Report())
+}
+
+// PrintYAML prints the resolved topology to the writer formatted as YAML.
+func (r *Resolver) PrintYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(r.Report()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// PrintDot prints the resolved topology to the writer as a Graphviz directed
+// graph. The edges point from the dependency to the charts it depends on, only
+// charts in the topology are linked.
+func (r *Resolver) PrintDot(w io.Writer) {
+	fmt.Fprintln(w, "digraph topology {")
+	fmt.Fprintln(w, "  rankdir=\"BT\";")
+	fmt.Fprintln(w, "  node [shape=box];")
+	for _, d := range r.topology.Dependencies() {
+		label := fmt.Sprintf("%s\\n(%s)", d.Name(), d.Namespace())
+		if product := d.ProductName(); product != "" {
+			label = fmt.Sprintf("%s\\n%s", label, product)
+		}
+		fmt.Fprintf(w, "  %q [label=%q];\n", d.Name(), label)
+	}
+	for _, d := range r.topology.Dependencies() {
+		for _, dependsOn := range d.DependsOn() {
+			if r.topology.Contains(dependsOn) {
+				fmt.Fprintf(w, "  %q -> %q;\n", d.Name(), dependsOn)
+			}
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// PrintMermaid prints the resolved topology to the writer as a Mermaid
+// flowchart. The edges point from the dependency to the charts it depends on,
+// only charts in the topology are linked.
+func (r *Resolver) PrintMermaid(w io.Writer) {
+	fmt.Fprintln(w, "flowchart BT")
+	for _, d := range r.topology.Dependencies() {
+		label := []string{d.Name(), fmt.Sprintf("(%s)", d.Namespace())}
+		if product := d.ProductName(); product != "" {
+			label = append(label, product)
+		}
+		fmt.Fprintf(w, "  %s[\"%s\"]\n",
+			nodeID(d.Name()), strings.Join(label, "<br/>"))
+	}
+	for _, d := range r.topology.Dependencies() {
+		for _, dependsOn := range d.DependsOn() {
+			if r.topology.Contains(dependsOn) {
+				fmt.Fprintf(w, "  %s --> %s\n",
+					nodeID(d.Name()), nodeID(dependsOn))
+			}
+		}
+	}
+}
+
+// PrintFormat prints the resolved topology to the writer using the informed
+// output format.
+func (r *Resolver) PrintFormat(w io.Writer, format OutputFormat) error {
+	switch format {
+	case TableOutput:
+		r.Print(w)
+	case JSONOutput:
+		return r.PrintJSON(w)
+	case YAMLOutput:
+		return r.PrintYAML(w)
+	case DotOutput:
+		r.PrintDot(w)
+	case MermaidOutput:
+		r.PrintMermaid(w)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedOutputFormat, format)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package resolver
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestResolver_PrintFormat(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cfs, err := chartfs.NewChartFS("../../installer")
+	g.Expect(err).To(o.Succeed())
+
+	cfg, err := config.NewConfigFromFile(cfs, "config.yaml")
+	g.Expect(err).To(o.Succeed())
+
+	charts, err := cfs.GetAllCharts()
+	g.Expect(err).To(o.Succeed())
+
+	c, err := NewCollection(charts)
+	g.Expect(err).To(o.Succeed())
+
+	r := NewResolver(cfg, c, NewTopology())
+	g.Expect(r.Resolve()).To(o.Succeed())
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		g.Expect(r.PrintFormat(&buf, JSONOutput)).To(o.Succeed())
+
+		var report TopologyReport
+		g.Expect(json.Unmarshal(buf.Bytes(), &report)).To(o.Succeed())
+		g.Expect(report.Dependencies).To(o.HaveLen(14))
+		g.Expect(report.Dependencies[0].Index).To(o.Equal(1))
+		g.Expect(report.Dependencies[0].Name).To(o.Equal("tssc-openshift"))
+
+		dh := report.Dependencies[12]
+		g.Expect(dh.Name).To(o.Equal("tssc-dh"))
+		g.Expect(dh.Namespace).To(o.Equal("tssc-dh"))
+		g.Expect(dh.Product).To(o.Equal("Developer Hub"))
+		g.Expect(dh.DependsOn).To(o.ContainElement("tssc-app-namespaces"))
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		var buf bytes.Buffer
+		g.Expect(r.PrintFormat(&buf, YAMLOutput)).To(o.Succeed())
+		g.Expect(buf.String()).To(o.ContainSubstring("name: tssc-dh"))
+		g.Expect(buf.String()).To(o.ContainSubstring("product: Developer Hub"))
+	})
+
+	t.Run("Dot", func(t *testing.T) {
+		var buf bytes.Buffer
+		g.Expect(r.PrintFormat(&buf, DotOutput)).To(o.Succeed())
+		g.Expect(buf.String()).To(o.HavePrefix("digraph topology {"))
+		g.Expect(buf.String()).
+			To(o.ContainSubstring(`"tssc-dh" -> "tssc-app-namespaces";`))
+	})
+
+	t.Run("Mermaid", func(t *testing.T) {
+		var buf bytes.Buffer
+		g.Expect(r.PrintFormat(&buf, MermaidOutput)).To(o.Succeed())
+		g.Expect(buf.String()).To(o.HavePrefix("flowchart BT"))
+		g.Expect(buf.String()).
+			To(o.ContainSubstring("tssc_dh --> tssc_app_namespaces"))
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := r.PrintFormat(&buf, OutputFormat("xml"))
+		g.Expect(err).To(o.MatchError(o.ContainSubstring("xml")))
+	})
+}
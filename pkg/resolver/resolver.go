@@ -3,10 +3,14 @@ package resolver
 import (
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+
+	"github.com/Masterminds/semver/v3"
 )
 
 // Resolver represents the actor that resolves dependencies between charts.
@@ -14,6 +18,11 @@ type Resolver struct {
 	cfg        *config.Config // installer configuration
 	collection *Collection    // collection of charts
 	topology   *Topology      // topology of dependencies
+
+	resolved  Dependencies        // resolved charts, in discovery order
+	alongside map[string]bool     // charts added alongside the charts they require
+	reasons   map[string][]reason // why each chart is part of the topology
+	skipped   map[string]string   // charts skipped by "enabled-when" condition
 }
 
 // ErrCircularDependency reports a circular dependency.
@@ -22,6 +31,26 @@ var ErrCircularDependency = fmt.Errorf("circular dependency detected")
 // ErrMissingDependency reports an unmet dependency.
 var ErrMissingDependency = fmt.Errorf("unmet dependency detected")
 
+// ErrIncompatibleDependency reports a required chart version not satisfying the
+// version constraint.
+var ErrIncompatibleDependency = fmt.Errorf("incompatible dependency version")
+
+// isResolved checks if the chart is already resolved.
+func (r *Resolver) isResolved(name string) bool {
+	return slices.ContainsFunc(r.resolved, func(d Dependency) bool {
+		return d.Name() == name
+	})
+}
+
+// resolve adds the dependency to the resolved charts, when not yet resolved.
+func (r *Resolver) resolve(d Dependency) bool {
+	if r.isResolved(d.Name()) {
+		return false
+	}
+	r.resolved = append(r.resolved, d)
+	return true
+}
+
 // setDependencyNamespace sets the desired namespace on the informed dependency.
 // By default, charts are deployed on the same namespace than the installer, while
 // product assossiated dependencies will use the namespace configured for it.
@@ -54,21 +83,19 @@ func (r *Resolver) setDependencyNamespace(d *Dependency) error {
 }
 
 // dependsOn checks if the chart has dependencies and resolves them. The
-// dependencies are prepended to the parent chart and when more dependencies are
-// found, they are also resolved.
+// dependencies are resolved before the chart itself, recursively, and the path
+// of charts being resolved is used to detect circular dependencies.
 func (r *Resolver) dependsOn(
-	parent string, // partent chart name
 	d *Dependency, // dependency instance
-	visited map[string]bool, // visited charts
+	path []string, // charts being resolved, leading to the dependency
 ) error {
-	// Ensure the chart is not visited again, to prevent circular dependencies.
+	// Ensure the chart is not in the path again, to prevent circular dependencies.
 	dependencyName := d.Name()
-	if visited[dependencyName] {
-		return fmt.Errorf("%w: a %q dependency requires %q",
-			ErrCircularDependency, dependencyName, dependencyName)
+	if i := slices.Index(path, dependencyName); i >= 0 {
+		return fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(
+			append(slices.Clone(path[i:]), dependencyName), " -> "))
 	}
-	visited[dependencyName] = true
-	defer delete(visited, dependencyName)
+	path = append(path, dependencyName)
 
 	for _, dependsOn := range d.DependsOn() {
 		// Picking up the dependency from the collection by name.
@@ -86,17 +113,26 @@ func (r *Resolver) dependsOn(
 				continue
 			}
 		}
-		// Setting the correct namespace in the dependency.
-		if err := r.setDependencyNamespace(dependsOnDep); err != nil {
+		// Skipping when the next dependency condition is not met.
+		if enabled, err := r.isEnabled(dependsOnDep); err != nil {
 			return err
+		} else if !enabled {
+			continue
 		}
-		// Adding the Helm chart to the topology before the parent chart. The
-		// namespace is the installer's default.
-		r.topology.PrependBefore(parent, *dependsOnDep)
-		// Recursively resolving the dependencies.
-		if err = r.dependsOn(dependsOn, dependsOnDep, visited); err != nil {
+		// Product charts are only part of the topology when the product is
+		// enabled, thus only regular charts are required by other charts.
+		if dependsOnDep.ProductName() == "" {
+			r.addReason(dependsOn, reason{requiredBy: dependencyName})
+		}
+		// Recursively resolving the dependencies, before the Helm chart itself.
+		if err = r.dependsOn(dependsOnDep, path); err != nil {
+			return err
+		}
+		// Setting the correct namespace in the dependency.
+		if err := r.setDependencyNamespace(dependsOnDep); err != nil {
 			return err
 		}
+		r.resolve(*dependsOnDep)
 	}
 	return nil
 }
@@ -104,17 +140,25 @@ func (r *Resolver) dependsOn(
 // resolveEnabledProducts resolves the dependencies of enabled products.
 func (r *Resolver) resolveEnabledProducts() error {
 	for _, product := range r.cfg.GetEnabledProducts() {
-		d, err := r.collection.GetProductDependency(product.Name)
+		dependencies, err := r.collection.GetProductDependencies(product.Name)
 		if err != nil {
 			return err
 		}
-		// Products uses the namespace specified in the configuration.
-		d.SetNamespace(*product.Namespace)
-		// Product charts are added to the topology before required charts.
-		r.topology.Append(*d)
-		// Recursively resolving the dependencies, added before this chart.
-		if err = r.dependsOn(d.Name(), d, map[string]bool{}); err != nil {
-			return err
+		for _, d := range dependencies {
+			// Skipping the product chart when its condition is not met.
+			if enabled, err := r.isEnabled(d); err != nil {
+				return err
+			} else if !enabled {
+				continue
+			}
+			r.addReason(d.Name(), reason{product: product.Name})
+			// Recursively resolving the dependencies, before the product chart.
+			if err = r.dependsOn(d, []string{}); err != nil {
+				return err
+			}
+			// Products uses the namespace specified in the configuration.
+			d.SetNamespace(*product.Namespace)
+			r.resolve(*d)
 		}
 	}
 	return nil
@@ -122,21 +166,21 @@ func (r *Resolver) resolveEnabledProducts() error {
 
 // resolveDependencies final inspection of the Helm charts in the Collection to
 // ensure all dependencies are met. It walks the charts in the Collection, and for
-// each entry verifies it it depends on any chart in the Topology.
+// each entry verifies it it depends on any resolved chart, when it does the chart
+// is added alongside the charts it requires.
 func (r *Resolver) resolveDependencies() error {
 	return r.collection.Walk(func(name string, d Dependency) error {
 		// Skip dependencies that are associated with a product. These have
-		// already been added to the topology.
+		// already been resolved.
 		if product := d.ProductName(); product != "" {
 			return nil
 		}
-		// Collecting the last dependency name that is required by the current
-		// chart (dependency), if any.
-		requiredDependency := ""
+		// Checking whether the current chart (dependency) requires any resolved
+		// chart.
+		required := []string{}
 		for _, dependsOn := range d.DependsOn() {
-			// Ensure the required dependency is in the topology, when not in the
-			// topology it is skipped.
-			if !r.topology.Contains(dependsOn) {
+			// Ensure the required dependency is resolved, otherwise skipped.
+			if !r.isResolved(dependsOn) {
 				continue
 			}
 			// Ensures the required dependency is in the collection.
@@ -148,30 +192,175 @@ func (r *Resolver) resolveDependencies() error {
 					name,
 				)
 			}
-			requiredDependency = dependsOn
+			required = append(required, dependsOn)
 		}
 		// If there is no required dependency, skip it.
-		if requiredDependency == "" {
+		if len(required) == 0 {
 			return nil
 		}
+		// Skipping the chart when its condition is not met.
+		if enabled, err := r.isEnabled(&d); err != nil || !enabled {
+			return err
+		}
+		for _, dependsOn := range required {
+			r.addReason(name, reason{requires: dependsOn})
+		}
+		// Recursively resolve dependencies.
+		if err := r.dependsOn(&d, []string{}); err != nil {
+			return err
+		}
 		// Setting the desired namespace in the dependency.
 		if err := r.setDependencyNamespace(&d); err != nil {
 			return err
 		}
-		// Append the current dependency after the last one in the collection that
-		// requires it.
-		r.topology.AppendAfter(requiredDependency, d)
-		// Recursively resolve dependencies.
-		return r.dependsOn(name, &d, map[string]bool{})
+		if r.resolve(d) {
+			r.alongside[name] = true
+		}
+		return nil
 	})
 }
 
+// checkVersions ensures the resolved charts satisfy the version constraints of
+// the resolved charts requiring them.
+func (r *Resolver) checkVersions() error {
+	for _, d := range r.resolved {
+		for _, requirement := range d.Requirements() {
+			constraints, err := requirement.Constraints()
+			if err != nil {
+				return fmt.Errorf("%w: chart %s: invalid constraint %q: %s",
+					ErrIncompatibleDependency, d.Name(), requirement.String(), err)
+			}
+			if constraints == nil {
+				continue
+			}
+			i := slices.IndexFunc(r.resolved, func(required Dependency) bool {
+				return required.Name() == requirement.Name
+			})
+			if i < 0 {
+				continue
+			}
+			required := r.resolved[i]
+			version, err := semver.NewVersion(required.Version())
+			if err != nil {
+				return fmt.Errorf("%w: chart %s requires %q, invalid version %q: %s",
+					ErrIncompatibleDependency,
+					d.Name(),
+					requirement.String(),
+					required.Version(),
+					err,
+				)
+			}
+			if !constraints.Check(version) {
+				return fmt.Errorf("%w: chart %s requires %q, found version %s",
+					ErrIncompatibleDependency,
+					d.Name(),
+					requirement.String(),
+					version,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// findCycle returns a circular path between the informed charts, where every
+// chart depends on at least another chart in the informed set.
+func findCycle(dependencies map[string]Dependency) []string {
+	names := slices.Sorted(maps.Keys(dependencies))
+	path := []string{}
+	for name := names[0]; ; {
+		if i := slices.Index(path, name); i >= 0 {
+			return append(path[i:], name)
+		}
+		path = append(path, name)
+		d := dependencies[name]
+		for _, dependsOn := range d.DependsOn() {
+			if _, exists := dependencies[dependsOn]; exists {
+				name = dependsOn
+				break
+			}
+		}
+	}
+}
+
+// sort orders the resolved charts using Kahn's algorithm, a chart is only added
+// to the topology after all the charts it depends on. Among the charts ready to
+// be added, the ones resolved alongside the charts they require come first, and
+// then the discovery order is followed.
+func (r *Resolver) sort() error {
+	// Pending charts, and the number of resolved charts each one depends on.
+	pending := map[string]Dependency{}
+	inDegree := map[string]int{}
+	for _, d := range r.resolved {
+		pending[d.Name()] = d
+		for _, dependsOn := range d.DependsOn() {
+			if r.isResolved(dependsOn) {
+				inDegree[d.Name()]++
+			}
+		}
+	}
+
+	for range r.resolved {
+		// Selecting the next chart ready to be added to the topology.
+		next := -1
+		for i, d := range r.resolved {
+			if _, exists := pending[d.Name()]; !exists || inDegree[d.Name()] > 0 {
+				continue
+			}
+			if next < 0 ||
+				(r.alongside[d.Name()] && !r.alongside[r.resolved[next].Name()]) {
+				next = i
+			}
+		}
+		if next < 0 {
+			return fmt.Errorf("%w: %s", ErrCircularDependency,
+				strings.Join(findCycle(pending), " -> "))
+		}
+
+		d := r.resolved[next]
+		r.topology.Append(d)
+		delete(pending, d.Name())
+		// Releasing the charts depending on the chart added to the topology.
+		for _, p := range pending {
+			for _, dependsOn := range p.DependsOn() {
+				if dependsOn == d.Name() {
+					inDegree[p.Name()]--
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // Resolve resolves the all dependencies in the collection to create the topology.
 func (r *Resolver) Resolve() error {
 	if err := r.resolveEnabledProducts(); err != nil {
 		return err
 	}
-	return r.resolveDependencies()
+	if err := r.resolveDependencies(); err != nil {
+		return err
+	}
+	if err := r.checkVersions(); err != nil {
+		return err
+	}
+	return r.sort()
+}
+
+// ResolveWithout resolves a new topology using the same configuration and
+// collection, with the informed products disabled.
+func (r *Resolver) ResolveWithout(products ...string) (*Topology, error) {
+	cfg := *r.cfg
+	cfg.Installer.Products = slices.Clone(r.cfg.Installer.Products)
+	for i, p := range cfg.Installer.Products {
+		if slices.Contains(products, p.Name) {
+			cfg.Installer.Products[i].Enabled = false
+		}
+	}
+	t := NewTopology()
+	if err := NewResolver(&cfg, r.collection, t).Resolve(); err != nil {
+		return nil, err
+	}
+	return t, nil
 }
 
 // Print prints the resolved topology to the writer formatted as a table.
@@ -192,6 +381,17 @@ func (r *Resolver) Print(w io.Writer) {
 		)
 	}
 	table.Flush()
+
+	// Listing the charts skipped by their "enabled-when" condition.
+	if len(r.skipped) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nSkipped (enabled-when):")
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range slices.Sorted(maps.Keys(r.skipped)) {
+		fmt.Fprintf(table, "  %s\t%s\n", name, r.skipped[name])
+	}
+	table.Flush()
 }
 
 // NewResolver instantiates a new Resolver. It takes the configuration, collection
@@ -201,5 +401,9 @@ func NewResolver(cfg *config.Config, c *Collection, t *Topology) *Resolver {
 		cfg:        cfg,
 		collection: c,
 		topology:   t,
+		resolved:   Dependencies{},
+		alongside:  map[string]bool{},
+		reasons:    map[string][]reason{},
+		skipped:    map[string]string{},
 	}
 }
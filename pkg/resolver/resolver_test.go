@@ -1,11 +1,14 @@
 package resolver
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
 
+	"helm.sh/helm/v3/pkg/chart"
+
 	o "github.com/onsi/gomega"
 )
 
@@ -81,5 +84,298 @@ func TestNewResolver(t *testing.T) {
 			"tssc-dh",
 			"tssc-integrations",
 		}))
+
+		// Grouping the resolved dependencies by level, charts on the same level
+		// don't depend on each other.
+		levels := [][]string{}
+		for _, level := range topology.Levels() {
+			names := []string{}
+			for _, d := range level {
+				names = append(names, d.Name())
+			}
+			levels = append(levels, names)
+		}
+		g.Expect(levels).To(o.Equal([][]string{
+			{"tssc-openshift"},
+			{"tssc-subscriptions"},
+			{"tssc-acs", "tssc-gitops", "tssc-infrastructure"},
+			{"tssc-acs-test", "tssc-iam"},
+			{"tssc-tas", "tssc-tpa-realm"},
+			{"tssc-pipelines", "tssc-tpa"},
+			{"tssc-app-namespaces"},
+			{"tssc-dh"},
+			{"tssc-integrations"},
+		}))
+	})
+	t.Run("TPADisabled", func(t *testing.T) {
+		cfg, err := config.NewConfigFromFile(cfs, "config.yaml")
+		g.Expect(err).To(o.Succeed())
+		for _, product := range []string{
+			"Trusted Profile Analyzer", "Advanced Cluster Security",
+		} {
+			g.Expect(cfg.Set(
+				"products["+product+"].enabled", "false")).To(o.Succeed())
+		}
+
+		// The realm import belongs to the disabled product, thus it's not
+		// deployed in the product namespace.
+		topology := NewTopology()
+		g.Expect(NewResolver(cfg, c, topology).Resolve()).To(o.Succeed())
+		g.Expect(topology.Contains("tssc-tpa")).To(o.BeFalse())
+		g.Expect(topology.Contains("tssc-tpa-realm")).To(o.BeFalse())
+		g.Expect(topology.Contains("tssc-iam")).To(o.BeTrue())
+	})
+	t.Run("ResolveWithout", func(t *testing.T) {
+		r := NewResolver(cfg, c, NewTopology())
+		topology, err := r.ResolveWithout("Developer Hub")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(topology.Contains("tssc-dh")).To(o.BeFalse())
+		g.Expect(topology.Contains("tssc-integrations")).To(o.BeTrue())
+		// The original configuration is not modified.
+		product, err := cfg.GetProduct("Developer Hub")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(product.Enabled).To(o.BeTrue())
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		r := NewResolver(cfg, c, NewTopology())
+		g.Expect(r.Resolve()).To(o.Succeed())
+
+		// A product chart is removed by disabling its product.
+		e, err := r.Explain("tssc-dh")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(e.Namespace).To(o.Equal("tssc-dh"))
+		g.Expect(e.Chains).To(o.HaveLen(1))
+		g.Expect(e.Toggles).To(o.Equal([]string{"Developer Hub"}))
+		g.Expect(e.AllToggles).To(o.BeFalse())
+
+		// Charts depending on a product chart are added alongside it.
+		e, err = r.Explain("tssc-acs-test")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(e.Chains).To(o.Equal([]Chain{{
+			Product: "Advanced Cluster Security",
+			Steps: []string{
+				`product "Advanced Cluster Security" is enabled, chart "tssc-acs"`,
+				`"tssc-acs-test" depends-on "tssc-acs", added alongside it`,
+			},
+		}}))
+		g.Expect(e.Toggles).To(o.Equal([]string{"Advanced Cluster Security"}))
+
+		// Charts required by several products are only removed when all of them
+		// are disabled.
+		e, err = r.Explain("tssc-integrations")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(e.Toggles).To(o.ConsistOf(
+			"Advanced Cluster Security", "OpenShift GitOps", "Developer Hub",
+		))
+		g.Expect(e.AllToggles).To(o.BeTrue())
+
+		var buf bytes.Buffer
+		e.Print(&buf)
+		g.Expect(buf.String()).To(o.ContainSubstring(
+			"tssc.products[Developer Hub].enabled: false"))
+
+		_, err = r.Explain("tssc-unknown")
+		g.Expect(err).To(o.MatchError(ErrChartNotInTopology))
+	})
+}
+
+// newTestChart creates a Helm chart with the informed metadata, for testing.
+func newTestChart(name, version, product, dependsOn string) chart.Chart {
+	return chart.Chart{Metadata: &chart.Metadata{
+		Name:    name,
+		Version: version,
+		Annotations: map[string]string{
+			ProductNameAnnotation: product,
+			DependsOnAnnotation:   dependsOn,
+		},
+	}}
+}
+
+// newTestConfig creates a configuration with a single enabled product.
+func newTestConfig(g *o.WithT) *config.Config {
+	cfg, err := config.NewConfigFromBytes([]byte(`
+tssc:
+  namespace: tssc
+  settings:
+    crc: true
+  products:
+    - name: Product
+      enabled: true
+      namespace: product
+      properties:
+        mode: minimal
+`))
+	g.Expect(err).To(o.Succeed())
+	return cfg
+}
+
+func TestResolverCircularDependency(t *testing.T) {
+	g := o.NewWithT(t)
+
+	c, err := NewCollection([]chart.Chart{
+		newTestChart("product", "1.0.0", "Product", "a"),
+		newTestChart("a", "1.0.0", "", "b"),
+		newTestChart("b", "1.0.0", "", "c"),
+		newTestChart("c", "1.0.0", "", "a"),
+	})
+	g.Expect(err).To(o.Succeed())
+
+	err = NewResolver(newTestConfig(g), c, NewTopology()).Resolve()
+	g.Expect(err).To(o.MatchError(ErrCircularDependency))
+	g.Expect(err.Error()).To(o.HaveSuffix(": a -> b -> c -> a"))
+}
+
+func TestResolverVersionConstraints(t *testing.T) {
+	g := o.NewWithT(t)
+
+	t.Run("Satisfied", func(t *testing.T) {
+		c, err := NewCollection([]chart.Chart{
+			newTestChart("product", "1.0.0", "Product", "a@^1.2, b"),
+			newTestChart("a", "1.3.0", "", "b@>= 2.0 < 3.0"),
+			newTestChart("b", "2.1.0", "", ""),
+		})
+		g.Expect(err).To(o.Succeed())
+
+		topology := NewTopology()
+		g.Expect(NewResolver(newTestConfig(g), c, topology).Resolve()).
+			To(o.Succeed())
+		names := []string{}
+		for _, d := range topology.Dependencies() {
+			names = append(names, d.Name())
+		}
+		g.Expect(names).To(o.Equal([]string{"b", "a", "product"}))
+	})
+
+	t.Run("Unsatisfied", func(t *testing.T) {
+		c, err := NewCollection([]chart.Chart{
+			newTestChart("product", "1.0.0", "Product", "a@^2.0"),
+			newTestChart("a", "1.3.0", "", ""),
+		})
+		g.Expect(err).To(o.Succeed())
+
+		err = NewResolver(newTestConfig(g), c, NewTopology()).Resolve()
+		g.Expect(err).To(o.MatchError(ErrIncompatibleDependency))
+		g.Expect(err.Error()).To(o.ContainSubstring(
+			`chart product requires "a@^2.0", found version 1.3.0`))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewCollection([]chart.Chart{
+			newTestChart("product", "1.0.0", "Product", "a@not-a-constraint"),
+			newTestChart("a", "1.3.0", "", ""),
+		})
+		g.Expect(err).To(o.MatchError(ErrInvalidCollection))
+	})
+}
+
+func TestResolverEnabledWhen(t *testing.T) {
+	g := o.NewWithT(t)
+
+	newConditionalChart := func(name, dependsOn, condition string) chart.Chart {
+		hc := newTestChart(name, "1.0.0", "", dependsOn)
+		hc.Metadata.Annotations[EnabledWhenAnnotation] = condition
+		return hc
+	}
+	c, err := NewCollection([]chart.Chart{
+		newTestChart("product", "1.0.0", "Product", "a"),
+		newConditionalChart("a", "", "not settings.crc"),
+		newConditionalChart("b", "product",
+			`eq products.Product.properties.mode "full"`),
+		newConditionalChart("c", "product", "settings.crc"),
+	})
+	g.Expect(err).To(o.Succeed())
+
+	topology := NewTopology()
+	r := NewResolver(newTestConfig(g), c, topology)
+	g.Expect(r.Resolve()).To(o.Succeed())
+
+	names := []string{}
+	for _, d := range topology.Dependencies() {
+		names = append(names, d.Name())
+	}
+	g.Expect(names).To(o.Equal([]string{"product", "c"}))
+	g.Expect(r.Report().Skipped).To(o.Equal([]SkippedReport{
+		{Name: "a", EnabledWhen: "not settings.crc"},
+		{Name: "b", EnabledWhen: `eq products.Product.properties.mode "full"`},
+	}))
+
+	var buf bytes.Buffer
+	r.Print(&buf)
+	g.Expect(buf.String()).To(o.ContainSubstring("Skipped (enabled-when):"))
+
+	_, err = r.Explain("a")
+	g.Expect(err).To(o.MatchError(ErrChartNotInTopology))
+	g.Expect(err.Error()).To(o.ContainSubstring("skipped by enabled-when"))
+
+	t.Run("ProductProperties", func(t *testing.T) {
+		cfg, err := config.NewConfigFromBytes([]byte(`
+tssc:
+  namespace: tssc
+  settings: {}
+  products:
+    - name: Developer Hub
+      enabled: true
+      namespace: tssc-dh
+      properties:
+        authProvider: gitlab
+`))
+		g.Expect(err).To(o.Succeed())
+
+		condition := `eq products.Developer_Hub.properties.authProvider "gitlab"`
+		c, err := NewCollection([]chart.Chart{
+			newTestChart("tssc-dh", "1.0.0", "Developer Hub", ""),
+			newConditionalChart("gitlab", "tssc-dh", condition),
+		})
+		g.Expect(err).To(o.Succeed())
+
+		topology := NewTopology()
+		g.Expect(NewResolver(cfg, c, topology).Resolve()).To(o.Succeed())
+		names := []string{}
+		for _, d := range topology.Dependencies() {
+			names = append(names, d.Name())
+		}
+		g.Expect(names).To(o.Equal([]string{"tssc-dh", "gitlab"}))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewCollection([]chart.Chart{
+			newConditionalChart("a", "", "not (settings.crc"),
+		})
+		g.Expect(err).To(o.MatchError(ErrInvalidCollection))
+	})
+}
+
+func TestResolverMultipleChartsPerProduct(t *testing.T) {
+	g := o.NewWithT(t)
+
+	c, err := NewCollection([]chart.Chart{
+		newTestChart("addon", "1.0.0", "Product", "base"),
+		newTestChart("base", "1.0.0", "Product", "common"),
+		newTestChart("common", "1.0.0", "", ""),
 	})
+	g.Expect(err).To(o.Succeed())
+
+	cfg := newTestConfig(g)
+	topology := NewTopology()
+	g.Expect(NewResolver(cfg, c, topology).Resolve()).To(o.Succeed())
+
+	namespaces := map[string]string{}
+	names := []string{}
+	for _, d := range topology.Dependencies() {
+		names = append(names, d.Name())
+		namespaces[d.Name()] = d.Namespace()
+	}
+	g.Expect(names).To(o.Equal([]string{"common", "base", "addon"}))
+	g.Expect(namespaces).To(o.Equal(map[string]string{
+		"common": "tssc",
+		"base":   "product",
+		"addon":  "product",
+	}))
+
+	// Disabling the product removes all of its charts.
+	cfg.Installer.Products[0].Enabled = false
+	topology = NewTopology()
+	g.Expect(NewResolver(cfg, c, topology).Resolve()).To(o.Succeed())
+	g.Expect(topology.Dependencies()).To(o.BeEmpty())
 }
@@ -35,70 +35,50 @@ func (t *Topology) Contains(name string) bool {
 	return false
 }
 
-// PrependBefore prepends a list of dependencies before a specific dependency.
-func (t *Topology) PrependBefore(name string, dependencies ...Dependency) {
-	prefix := Dependencies{}
-	for _, dependency := range dependencies {
-		if !t.Contains(dependency.Name()) {
-			prefix = append(prefix, dependency)
+// Dependents returns the dependencies depending on the informed one, directly or
+// transitively, in topology order.
+func (t *Topology) Dependents(name string) Dependencies {
+	dependents := map[string]bool{name: true}
+	result := Dependencies{}
+	// Dependents always come after the dependency in the topology, so a single
+	// pass collects the transitive dependents.
+	for _, d := range t.dependencies {
+		if dependents[d.Name()] {
+			continue
 		}
-	}
-	if len(prefix) == 0 {
-		return
-	}
-
-	// Find the index where the dependency name exists.
-	insertIndex := -1
-	for i, d := range t.dependencies {
-		if d.Name() == name {
-			insertIndex = i
-			break
+		for _, dependsOn := range d.DependsOn() {
+			if dependents[dependsOn] {
+				dependents[d.Name()] = true
+				result = append(result, d)
+				break
+			}
 		}
 	}
-
-	// Insert the prefix slice before the found dependency name index. If the
-	// dependency is not found, prepend to the very beginning of the slice.
-	if insertIndex != -1 {
-		t.dependencies = append(
-			t.dependencies[:insertIndex],
-			append(prefix, t.dependencies[insertIndex:]...)...,
-		)
-	} else {
-		t.dependencies = append(prefix, t.dependencies...)
-	}
+	return result
 }
 
-// AppendAfter inserts dependencies after a given dependency name. If the
-// dependency does not exist, it appends to the end the slice.
-func (t *Topology) AppendAfter(name string, dependencies ...Dependency) {
-	suffix := Dependencies{}
-	for _, d := range dependencies {
-		if !t.Contains(d.Name()) {
-			suffix = append(suffix, d)
+// Levels groups the topology dependencies by dependency level. A dependency is
+// placed one level after the deepest chart it depends on, only considering the
+// charts present in the topology. Therefore, dependencies sharing the same level
+// don't depend on each other and can be installed concurrently. The topology
+// order is preserved within each level.
+func (t *Topology) Levels() []Dependencies {
+	levelByName := map[string]int{}
+	levels := []Dependencies{}
+	for _, d := range t.dependencies {
+		level := 0
+		for _, dependsOn := range d.DependsOn() {
+			if l, exists := levelByName[dependsOn]; exists && l >= level {
+				level = l + 1
+			}
 		}
-	}
-	if len(suffix) == 0 {
-		return
-	}
-
-	// Find the index where the dependency name exists.
-	insertIndex := -1
-	for i, d := range t.dependencies {
-		if d.Name() == name {
-			insertIndex = i
-			break
+		levelByName[d.Name()] = level
+		for len(levels) <= level {
+			levels = append(levels, Dependencies{})
 		}
+		levels[level] = append(levels[level], d)
 	}
-	// Insert the suffix slice after the found dependency name index. If the
-	// dependency is not found, append it.
-	if insertIndex != -1 {
-		t.dependencies = append(
-			t.dependencies[:insertIndex+1],
-			append(suffix, t.dependencies[insertIndex+1:]...)...,
-		)
-	} else {
-		t.dependencies = append(t.dependencies, suffix...)
-	}
+	return levels
 }
 
 // Append adds a new dependency to the end of the topology.
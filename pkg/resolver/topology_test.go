@@ -35,19 +35,12 @@ func TestNewTopology(t *testing.T) {
 	topology := NewTopology()
 
 	t.Run("Append", func(t *testing.T) {
+		topology.Append(*openShiftDep)
+		topology.Append(*subscriptionsDep)
+		topology.Append(*infrastructureDep)
 		topology.Append(*iamDep)
-	})
-
-	t.Run("PrependBefore", func(t *testing.T) {
-		topology.PrependBefore(
-			iamDep.Name(),
-			*openShiftDep,
-			*infrastructureDep,
-		)
-	})
-
-	t.Run("AppendAfter", func(t *testing.T) {
-		topology.AppendAfter(openShiftChart.Name(), *subscriptionsDep)
+		// Dependencies already in the topology are not appended again.
+		topology.Append(*openShiftDep)
 	})
 
 	t.Run("GetDependencies", func(t *testing.T) {
@@ -64,4 +57,33 @@ func TestNewTopology(t *testing.T) {
 			"tssc-iam",
 		}))
 	})
+	t.Run("Levels", func(t *testing.T) {
+		levels := topology.Levels()
+		names := [][]string{}
+		for _, level := range levels {
+			levelNames := []string{}
+			for _, d := range level {
+				levelNames = append(levelNames, d.Name())
+			}
+			names = append(names, levelNames)
+		}
+		g.Expect(names).To(o.Equal([][]string{
+			{"tssc-openshift"},
+			{"tssc-subscriptions"},
+			{"tssc-infrastructure"},
+			{"tssc-iam"},
+		}))
+	})
+
+	t.Run("Dependents", func(t *testing.T) {
+		names := []string{}
+		for _, d := range topology.Dependents("tssc-subscriptions") {
+			names = append(names, d.Name())
+		}
+		g.Expect(names).To(o.Equal([]string{
+			"tssc-infrastructure",
+			"tssc-iam",
+		}))
+		g.Expect(topology.Dependents("tssc-iam")).To(o.BeEmpty())
+	})
 }
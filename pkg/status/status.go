@@ -0,0 +1,211 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/deployer"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// StatusMissing the status of a dependency without a Helm release.
+const StatusMissing = "missing"
+
+// Release describes the Helm release status of a dependency in the topology.
+type Release struct {
+	// Chart the Helm chart name, also the release name.
+	Chart string `json:"chart"`
+	// Namespace the release namespace.
+	Namespace string `json:"namespace"`
+	// Product the product name associated with the Helm chart, if any.
+	Product string `json:"product,omitempty"`
+	// Status the latest release status, or "missing".
+	Status string `json:"status"`
+	// Revision the latest release revision, zero when missing.
+	Revision int `json:"revision"`
+	// ChartVersion the chart version of the latest release.
+	ChartVersion string `json:"chartVersion,omitempty"`
+	// EmbeddedVersion the chart version embedded in the installer.
+	EmbeddedVersion string `json:"embeddedVersion"`
+	// LastDeployed the latest release deployment time.
+	LastDeployed *time.Time `json:"lastDeployed,omitempty"`
+	// UpgradeAvailable the embedded chart version is newer than the deployed.
+	UpgradeAvailable bool `json:"upgradeAvailable"`
+	// Missing the release is not found in the cluster.
+	Missing bool `json:"missing"`
+	// Failed the latest release has failed.
+	Failed bool `json:"failed"`
+	// Pending the latest release is still installing, upgrading or rolling
+	// back, when it persists the release is stuck.
+	Pending bool `json:"pending"`
+}
+
+// Report describes the release status of all dependencies in the topology.
+type Report struct {
+	// Releases the release status of each dependency, in topology order.
+	Releases []Release `json:"releases"`
+	// Healthy all releases are deployed, none has failed or is pending.
+	Healthy bool `json:"healthy"`
+	// CollectedAt the time the status was collected.
+	CollectedAt time.Time `json:"collectedAt"`
+}
+
+// newerVersion checks if the embedded chart version is newer than the deployed,
+// versions not following semantic versioning are never considered newer.
+func newerVersion(embedded, deployed string) bool {
+	e, err := semver.NewVersion(embedded)
+	if err != nil {
+		return false
+	}
+	d, err := semver.NewVersion(deployed)
+	if err != nil {
+		return false
+	}
+	return e.GreaterThan(d)
+}
+
+// NewRelease describes the release status of the dependency based on the Helm
+// release history, the latest revision is employed.
+func NewRelease(dep resolver.Dependency, history []*release.Release) Release {
+	r := Release{
+		Chart:           dep.Name(),
+		Namespace:       dep.Namespace(),
+		Product:         dep.ProductName(),
+		Status:          StatusMissing,
+		EmbeddedVersion: dep.Chart().Metadata.Version,
+		Missing:         true,
+	}
+
+	var latest *release.Release
+	for _, rel := range history {
+		if latest == nil || rel.Version > latest.Version {
+			latest = rel
+		}
+	}
+	if latest == nil {
+		return r
+	}
+
+	r.Missing = false
+	r.Revision = latest.Version
+	if latest.Info != nil {
+		r.Status = latest.Info.Status.String()
+		r.Failed = latest.Info.Status == release.StatusFailed
+		r.Pending = latest.Info.Status.IsPending()
+		if !latest.Info.LastDeployed.IsZero() {
+			lastDeployed := latest.Info.LastDeployed.Time
+			r.LastDeployed = &lastDeployed
+		}
+	}
+	if latest.Chart != nil && latest.Chart.Metadata != nil {
+		r.ChartVersion = latest.Chart.Metadata.Version
+		r.UpgradeAvailable = newerVersion(r.EmbeddedVersion, r.ChartVersion)
+	}
+	return r
+}
+
+// NewReport describes the release status of the informed releases.
+func NewReport(releases []Release) *Report {
+	r := &Report{
+		Releases:    releases,
+		Healthy:     true,
+		CollectedAt: time.Now().UTC(),
+	}
+	for _, rel := range releases {
+		if rel.Missing || rel.Failed || rel.Pending {
+			r.Healthy = false
+		}
+	}
+	return r
+}
+
+// Collect queries the Helm history of each dependency, returns the release
+// status report.
+func Collect(
+	logger *slog.Logger,
+	f *flags.Flags,
+	kube *k8s.Kube,
+	deps resolver.Dependencies,
+) (*Report, error) {
+	releases := []Release{}
+	for _, dep := range deps {
+		hc, err := deployer.NewHelm(
+			dep.LoggerWith(logger),
+			f,
+			kube,
+			dep.Namespace(),
+			dep.Chart(),
+			io.Discard,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history, err := hc.History()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", dep.Name(), err)
+		}
+		releases = append(releases, NewRelease(dep, history))
+	}
+	return NewReport(releases), nil
+}
+
+// Print prints the report to the writer as a table.
+func (r *Report) Print(w io.Writer) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table,
+		"Chart\tNamespace\tStatus\tRevision\tVersion\tLast Deployed\tNotes")
+	for _, rel := range r.Releases {
+		revision, version, lastDeployed := "-", "-", "-"
+		if rel.Revision > 0 {
+			revision = fmt.Sprintf("%d", rel.Revision)
+		}
+		if rel.ChartVersion != "" {
+			version = rel.ChartVersion
+		}
+		if rel.LastDeployed != nil {
+			lastDeployed = rel.LastDeployed.Local().Format(time.DateTime)
+		}
+
+		notes := []string{}
+		switch {
+		case rel.Missing:
+			notes = append(notes, "release not found")
+		case rel.Failed:
+			notes = append(notes, "release failed")
+		case rel.Pending:
+			notes = append(notes, "release pending, may be stuck")
+		}
+		if rel.UpgradeAvailable {
+			notes = append(notes, fmt.Sprintf(
+				"embedded chart is newer (%s)", rel.EmbeddedVersion))
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			rel.Chart,
+			rel.Namespace,
+			rel.Status,
+			revision,
+			version,
+			lastDeployed,
+			strings.Join(notes, ", "),
+		)
+	}
+	_ = table.Flush()
+}
+
+// PrintJSON prints the report to the writer formatted as JSON.
+func (r *Report) PrintJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,98 @@
+package status
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
+
+	o "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+// newTestRelease creates a Helm release revision for the chart version.
+func newTestRelease(
+	revision int,
+	version string,
+	status release.Status,
+) *release.Release {
+	return &release.Release{
+		Name:    "tssc-test",
+		Version: revision,
+		Chart:   &chart.Chart{Metadata: &chart.Metadata{Version: version}},
+		Info: &release.Info{
+			Status: status,
+			LastDeployed: helmtime.Time{
+				Time: time.Date(2025, 1, revision, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+}
+
+func TestNewRelease(t *testing.T) {
+	g := o.NewWithT(t)
+
+	hc := &chart.Chart{Metadata: &chart.Metadata{
+		Name:    "tssc-test",
+		Version: "1.2.0",
+	}}
+	dep := *resolver.NewDependencyWithNamespace(hc, "tssc")
+
+	t.Run("Missing", func(t *testing.T) {
+		r := NewRelease(dep, nil)
+		g.Expect(r.Missing).To(o.BeTrue())
+		g.Expect(r.Status).To(o.Equal(StatusMissing))
+		g.Expect(r.Revision).To(o.BeZero())
+		g.Expect(r.EmbeddedVersion).To(o.Equal("1.2.0"))
+		g.Expect(NewReport([]Release{r}).Healthy).To(o.BeFalse())
+	})
+
+	t.Run("Deployed", func(t *testing.T) {
+		r := NewRelease(dep, []*release.Release{
+			newTestRelease(2, "1.1.0", release.StatusDeployed),
+			newTestRelease(1, "1.0.0", release.StatusSuperseded),
+		})
+		g.Expect(r.Missing).To(o.BeFalse())
+		g.Expect(r.Failed).To(o.BeFalse())
+		g.Expect(r.Status).To(o.Equal("deployed"))
+		g.Expect(r.Revision).To(o.Equal(2))
+		g.Expect(r.ChartVersion).To(o.Equal("1.1.0"))
+		g.Expect(r.UpgradeAvailable).To(o.BeTrue())
+		g.Expect(r.LastDeployed.Day()).To(o.Equal(2))
+		g.Expect(NewReport([]Release{r}).Healthy).To(o.BeTrue())
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		r := NewRelease(dep, []*release.Release{
+			newTestRelease(3, "1.2.0", release.StatusFailed),
+		})
+		g.Expect(r.Failed).To(o.BeTrue())
+		g.Expect(r.UpgradeAvailable).To(o.BeFalse())
+		g.Expect(NewReport([]Release{r}).Healthy).To(o.BeFalse())
+
+		var buf bytes.Buffer
+		NewReport([]Release{r}).Print(&buf)
+		g.Expect(buf.String()).To(o.ContainSubstring("release failed"))
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		for _, status := range []release.Status{
+			release.StatusPendingInstall,
+			release.StatusPendingUpgrade,
+		} {
+			r := NewRelease(dep, []*release.Release{
+				newTestRelease(4, "1.2.0", status),
+			})
+			g.Expect(r.Pending).To(o.BeTrue())
+			g.Expect(r.Failed).To(o.BeFalse())
+			g.Expect(NewReport([]Release{r}).Healthy).To(o.BeFalse())
+
+			var buf bytes.Buffer
+			NewReport([]Release{r}).Print(&buf)
+			g.Expect(buf.String()).To(o.ContainSubstring("release pending"))
+		}
+	})
+}
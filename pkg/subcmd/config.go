@@ -1,8 +1,13 @@
 package subcmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
@@ -23,13 +28,21 @@ type Config struct {
 	cfs    *chartfs.ChartFS // embedded filesystem
 	kube   *k8s.Kube        // kubernetes client
 
-	manager    *config.ConfigMapManager // cluster configuration manager
-	configPath string                   // configuration file relative path
-
-	create bool // create a new configuration
-	force  bool // overrides existing configuration
-	get    bool // show the current configuration
-	delete bool // delete the current configuration
+	manager     *config.ConfigMapManager // cluster configuration manager
+	configPaths []string                 // configuration files relative paths
+
+	create   bool  // create a new configuration
+	force    bool  // overrides existing configuration
+	get      bool  // show the current configuration
+	delete   bool  // delete the current configuration
+	validate bool  // validate a configuration file, offline
+	history  bool  // show the configuration revisions
+	rollback int   // configuration revision to roll back to
+	diff     []int // configuration revisions to compare
+	migrate  bool  // migrate the configuration to the current version
+
+	profiles     []string // configuration profiles layered on creation
+	listProfiles bool     // list the configuration profiles
 }
 
 var _ Interface = &Config{}
@@ -52,8 +65,46 @@ The "--create" flag reflects the creation of a new configuration while, "--force
 is meant to amend the cluster configuration and overwrite changes to installer's
 defaults.
 
+Multiple configuration files are merged in order, the first file is the base
+configuration and the following files are overlays on it. Objects are merged by
+attribute, and the products are merged by name, so an overlay can toggle a
+product, or change a single property. Use "--dry-run" to show the merged
+configuration. E.g.:
+	tssc config --create --dry-run installer/config.yaml crc.yaml
+
+The installer ships configuration profiles, overlays for common scenarios, use
+"--list-profiles" to describe them, and "--profile" to layer them on the
+configuration on creation, in order. E.g.:
+	tssc config --create --profile crc --profile ci
+
 This subcommand ensures a single cluster configuration is applied, identified and
 retrieved using a unique label selector.
+
+The configuration must comply with the installer configuration schema, unknown
+attributes and invalid values are rejected. Product charts may ship a
+"properties.schema.json" describing the product properties, the properties of
+enabled products are validated against it, the missing properties are filled
+with the schema defaults and unknown properties are rejected. Use "--validate"
+to check a local configuration file without a cluster, the problems are reported
+with the line number. E.g.:
+	tssc config --validate config.yaml
+
+The cluster configuration can be edited in place with the "set", "unset",
+"enable" and "disable" subcommands. E.g.:
+	tssc config enable "Trusted Profile Analyzer"
+
+Every update records the previous configuration as a revision, with the author,
+timestamp and CLI version. Use "--history" to list the revisions, "--diff" to
+compare two revisions, or a revision with the current configuration, and
+"--rollback" to restore a revision. E.g.:
+	tssc config --history
+	tssc config --diff 3,5
+	tssc config --rollback 3
+
+The configuration carries its version on "apiVersion", configurations created by
+previous installer versions are migrated in memory when read from the cluster.
+Use "--migrate" to show the changes and store the migrated configuration. E.g.:
+	tssc config --migrate
 `
 
 // Cmd exposes the cobra instance.
@@ -63,7 +114,7 @@ func (c *Config) Cmd() *cobra.Command {
 
 // log returns a decorated logger.
 func (c *Config) log() *slog.Logger {
-	return c.flags.LoggerWith(c.logger.With("config-path", c.configPath))
+	return c.flags.LoggerWith(c.logger.With("config-paths", c.configPaths))
 }
 
 // PersistentFlags injects the sub-command flags.
@@ -96,6 +147,48 @@ func (c *Config) PersistentFlags(p *pflag.FlagSet) {
 		false,
 		"Delete the current cluster configuration",
 	)
+	p.BoolVar(
+		&c.validate,
+		"validate",
+		false,
+		"Validate a local configuration file, without a cluster",
+	)
+	p.BoolVar(
+		&c.history,
+		"history",
+		false,
+		"Show the cluster configuration revisions",
+	)
+	p.IntVar(
+		&c.rollback,
+		"rollback",
+		0,
+		"Roll the cluster configuration back to the informed revision",
+	)
+	p.IntSliceVar(
+		&c.diff,
+		"diff",
+		[]int{},
+		"Compare two configuration revisions, or a revision with the current",
+	)
+	p.BoolVar(
+		&c.migrate,
+		"migrate",
+		false,
+		"Migrate the cluster configuration to the current version",
+	)
+	p.StringSliceVar(
+		&c.profiles,
+		"profile",
+		[]string{},
+		"Configuration profiles layered on the configuration, on creation",
+	)
+	p.BoolVar(
+		&c.listProfiles,
+		"list-profiles",
+		false,
+		"List the configuration profiles shipped with the installer",
+	)
 }
 
 // validateFlags validates the flags passed to the subcommand.
@@ -103,8 +196,38 @@ func (c *Config) validateFlags() error {
 	if c.get && c.delete {
 		return fmt.Errorf("cannot get and delete at the same time")
 	}
-	if !c.create && !c.force && !c.get && !c.delete {
-		return fmt.Errorf("either create, get or delete must be set")
+	if c.validate && (c.create || c.force || c.get || c.delete) {
+		return fmt.Errorf("validate cannot be combined with other actions")
+	}
+	revisionActions := 0
+	for _, set := range []bool{
+		c.history, c.rollback != 0, len(c.diff) > 0, c.migrate, c.listProfiles,
+	} {
+		if set {
+			revisionActions++
+		}
+	}
+	if revisionActions > 1 ||
+		(revisionActions == 1 && (c.create || c.force || c.delete || c.validate)) {
+		return fmt.Errorf(
+			"history, rollback, diff, migrate and list-profiles cannot be " +
+				"combined with other actions")
+	}
+	if len(c.profiles) > 0 && !c.create {
+		return fmt.Errorf("profile is only permitted for --create flag")
+	}
+	if c.rollback < 0 {
+		return fmt.Errorf("invalid rollback revision %d", c.rollback)
+	}
+	if len(c.diff) > 2 {
+		return fmt.Errorf("diff takes one or two revisions, %d informed",
+			len(c.diff))
+	}
+	if !c.create && !c.force && !c.get && !c.delete && !c.validate &&
+		revisionActions == 0 {
+		return fmt.Errorf(
+			"either create, get, delete, validate, history, rollback, diff, " +
+				"migrate or list-profiles must be set")
 	}
 	return nil
 }
@@ -112,22 +235,24 @@ func (c *Config) validateFlags() error {
 // Complete inspect the context to determine the path of the configuration file,
 // or uses the embedded payload, makes sure the args are adequate.
 func (c *Config) Complete(args []string) error {
-	// It should return an error if more than a single argument is informed.
-	if len(args) > 1 {
+	// Multiple configuration files are only merged on creation.
+	if len(args) > 1 && !c.create {
 		return fmt.Errorf("unexpected arguments: %v", args)
 	}
 	// It should inform a configuration file only for apply and update flags.
-	if (c.get || c.delete) && !c.create && len(args) > 0 {
+	revisionAction := c.history || c.rollback != 0 || len(c.diff) > 0 ||
+		c.migrate || c.listProfiles
+	if (c.get || c.delete || revisionAction) && !c.create && len(args) > 0 {
 		return fmt.Errorf(
 			"configuration file is only permitted for --create flag")
 	}
-	// Storing the configuration file reference, when empty using the embedded
+	// Storing the configuration files reference, when empty using the embedded
 	// default configuration path.
-	if len(args) == 1 {
-		c.configPath = args[0]
-		c.log().Debug("Using local configuration file")
+	if len(args) > 0 {
+		c.configPaths = args
+		c.log().Debug("Using local configuration files")
 	} else {
-		c.configPath = config.DefaultRelativeConfigPath
+		c.configPaths = []string{config.DefaultRelativeConfigPath}
 		c.log().Debug("Using embedded configuration file, default settings.")
 	}
 	return nil
@@ -135,7 +260,7 @@ func (c *Config) Complete(args []string) error {
 
 // Validate make sure all items are in place.
 func (c *Config) Validate() error {
-	if c.create && c.configPath == "" {
+	if c.create && len(c.configPaths) == 0 {
 		return fmt.Errorf("configuration file is not informed")
 	}
 	if err := c.validateFlags(); err != nil {
@@ -144,13 +269,72 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// applyPropertiesSchemas validates the properties of each enabled product against
+// the "properties.schema.json" shipped by the product charts, merged together,
+// filling in the defaults on the configuration.
+func applyPropertiesSchemas(
+	logger *slog.Logger,
+	cfs *chartfs.ChartFS,
+	cfg *config.Config,
+	collection *resolver.Collection,
+) error {
+	for _, product := range cfg.GetEnabledProducts() {
+		deps, err := collection.GetProductDependencies(product.Name)
+		if err != nil {
+			return err
+		}
+		schemas := [][]byte{}
+		for _, dep := range deps {
+			if schema := cfs.GetPropertiesSchema(dep.Chart()); schema != nil {
+				logger.Debug("Using product properties schema",
+					"product", product.Name, "chart", dep.Name())
+				schemas = append(schemas, schema)
+			}
+		}
+		if len(schemas) == 0 {
+			continue
+		}
+		if err = cfg.ApplyPropertiesSchema(product.Name, schemas...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyConfig ensures the configuration is compatible with the Helm charts
+// available for the installer, product properties, associated charts and
+// dependencies are verified.
+func verifyConfig(
+	logger *slog.Logger,
+	cfs *chartfs.ChartFS,
+	cfg *config.Config,
+) error {
+	charts, err := cfs.GetAllCharts()
+	if err != nil {
+		return err
+	}
+	collection, err := resolver.NewCollection(charts)
+	if err != nil {
+		return err
+	}
+	if err = applyPropertiesSchemas(logger, cfs, cfg, collection); err != nil {
+		return err
+	}
+	return resolver.NewResolver(cfg, collection, resolver.NewTopology()).Resolve()
+}
+
 // runCreate runs create action, makes sure a new configuration is applied in the
 // cluster and update when using the --force flag.
 func (c *Config) runCreate() error {
 	printer.Disclaimer()
 
 	c.log().Debug("Loading configuration from file")
-	cfg, err := config.NewConfigFromFile(c.cfs, c.configPath)
+	profilePaths, err := config.ProfilePaths(c.cfs, c.profiles...)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.NewConfigFromFiles(
+		c.cfs, slices.Concat(c.configPaths, profilePaths)...)
 	if err != nil {
 		return err
 	}
@@ -158,18 +342,10 @@ func (c *Config) runCreate() error {
 	// Ensuring the configuration is compabile with the Helm charts available for
 	// the installer, product associated charts and dependencies are verified.
 	c.log().Debug("Verifying installer Helm charts")
-	charts, err := c.cfs.GetAllCharts()
-	if err != nil {
-		return err
-	}
-	collection, err := resolver.NewCollection(charts)
-	if err != nil {
-		return err
-	}
-	r := resolver.NewResolver(cfg, collection, resolver.NewTopology())
-	if err = r.Resolve(); err != nil {
+	if err = verifyConfig(c.log(), c.cfs, cfg); err != nil {
 		return err
 	}
+	warnTopology(c.cmd.Context(), c.log(), c.kube, cfg)
 
 	if c.flags.DryRun {
 		c.log().Debug("[DRY-RUN] Only showing the configuration payload")
@@ -193,21 +369,68 @@ func (c *Config) runCreate() error {
 		return err
 	}
 
-	c.log().Debug("Applying the new configuration in the cluster")
-	if err = c.manager.Create(c.cmd.Context(), cfg); err != nil {
-		if apierrors.IsAlreadyExists(err) {
-			if c.force {
-				c.log().Debug("Updating the configuration in the cluster")
-				return c.manager.Update(c.cmd.Context(), cfg)
-			} else {
-				return fmt.Errorf(
-					"the configuration already exists, use --force to amend it")
-			}
+	// The existing configuration is read first, its resource version ensures the
+	// update fails when the ConfigMap changes concurrently.
+	cm, err := c.manager.GetConfigMap(c.cmd.Context())
+	switch {
+	case err == nil:
+		if !c.force {
+			return fmt.Errorf(
+				"the configuration already exists, use --force to amend it")
 		}
+		c.log().Debug("Updating the configuration in the cluster",
+			"resourceVersion", cm.GetResourceVersion())
+		cfg.SetResourceVersion(cm.GetResourceVersion())
+		return c.manager.Update(c.cmd.Context(), cfg)
+	case !errors.Is(err, config.ErrConfigMapNotFound):
+		return err
+	}
+
+	c.log().Debug("Applying the new configuration in the cluster")
+	err = c.manager.Create(c.cmd.Context(), cfg)
+	if apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("%w: the configuration was created concurrently",
+			config.ErrConfigConflict)
 	}
 	return err
 }
 
+// runValidate validates the configuration file against the schema and the
+// installer Helm charts, without a cluster.
+func (c *Config) runValidate() error {
+	c.log().Debug("Validating the configuration file")
+	configPath := c.configPaths[0]
+	payload, err := c.cfs.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	violations, err := config.ValidateSchema(payload)
+	if err != nil {
+		return err
+	}
+	for _, v := range violations {
+		fmt.Printf("%s: %s\n", configPath, v)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%w: %d problem(s) found on %q",
+			config.ErrInvalidConfig, len(violations), configPath)
+	}
+
+	cfg, err := config.NewConfigFromBytes(payload)
+	if err != nil {
+		return err
+	}
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+	if err = verifyConfig(c.log(), c.cfs, cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Configuration %q is valid (schema %s).\n",
+		configPath, config.SchemaVersion)
+	return nil
+}
+
 // runDelete controls the deletion process.
 func (c *Config) runDelete() error {
 	if c.flags.DryRun {
@@ -222,6 +445,195 @@ func (c *Config) runDelete() error {
 	return c.manager.Delete(c.cmd.Context())
 }
 
+// runHistory lists the configuration revisions recorded in the cluster.
+func (c *Config) runHistory() error {
+	c.log().Debug("Retrieving the cluster configuration revisions")
+	revisions, err := c.manager.History(c.cmd.Context())
+	if err != nil {
+		return err
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Revision\tAuthor\tTimestamp\tCLI Version\tCurrent")
+	for _, r := range revisions {
+		current := ""
+		if r.Current {
+			current = "*"
+		}
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n",
+			r.Number,
+			r.Author,
+			r.Timestamp.Local().Format(time.RFC3339),
+			r.CLIVersion,
+			current,
+		)
+	}
+	return table.Flush()
+}
+
+// currentRevision returns the current configuration revision.
+func (c *Config) currentRevision() (*config.Revision, error) {
+	revisions, err := c.manager.History(c.cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &revisions[len(revisions)-1], nil
+}
+
+// printDiff prints the differences between the configuration revisions.
+func printDiff(from, to *config.Revision) error {
+	diff, err := config.DiffRevisions(from, to)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		fmt.Printf("Revisions %d and %d are the same.\n", from.Number, to.Number)
+		return nil
+	}
+	fmt.Print(diff)
+	return nil
+}
+
+// runDiff compares the informed configuration revisions, or the informed
+// revision with the current configuration.
+func (c *Config) runDiff() error {
+	from, err := c.manager.GetRevision(c.cmd.Context(), c.diff[0])
+	if err != nil {
+		return err
+	}
+	var to *config.Revision
+	if len(c.diff) == 2 {
+		to, err = c.manager.GetRevision(c.cmd.Context(), c.diff[1])
+	} else {
+		to, err = c.currentRevision()
+	}
+	if err != nil {
+		return err
+	}
+	return printDiff(from, to)
+}
+
+// runRollback restores the informed configuration revision, the current
+// configuration is recorded as a revision as well.
+func (c *Config) runRollback() error {
+	c.log().Debug("Retrieving the configuration revision",
+		"revision", c.rollback)
+	target, err := c.manager.GetRevision(c.cmd.Context(), c.rollback)
+	if err != nil {
+		return err
+	}
+	if target.Current {
+		return fmt.Errorf("revision %d is the current configuration",
+			target.Number)
+	}
+	current, err := c.currentRevision()
+	if err != nil {
+		return err
+	}
+
+	// Revisions recorded by previous installer versions are migrated first.
+	payload, applied, err := config.Migrate([]byte(target.Payload))
+	if err != nil {
+		return fmt.Errorf("revision %d: %w", target.Number, err)
+	}
+	for _, m := range applied {
+		c.log().Debug("Migrating the configuration revision",
+			"revision", target.Number, "from", m.From, "to", m.To)
+	}
+	cfg, err := config.NewConfigFromBytes(payload)
+	if err != nil {
+		return fmt.Errorf("revision %d: %w", target.Number, err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("revision %d: %w", target.Number, err)
+	}
+	// The rollback is based on the current configuration shown on the diff.
+	cfg.SetResourceVersion(current.ResourceVersion)
+	c.log().Debug("Verifying installer Helm charts")
+	if err = verifyConfig(c.log(), c.cfs, cfg); err != nil {
+		return fmt.Errorf("revision %d: %w", target.Number, err)
+	}
+
+	if err = printDiff(current, target); err != nil {
+		return err
+	}
+	if c.flags.DryRun {
+		fmt.Printf("[DRY-RUN] Rolling the configuration back to revision %d\n",
+			target.Number)
+		return nil
+	}
+	if err = c.manager.Update(c.cmd.Context(), cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Configuration rolled back to revision %d.\n", target.Number)
+	return nil
+}
+
+// runMigrate migrates the cluster configuration to the current version, showing
+// the changes before storing it.
+func (c *Config) runMigrate() error {
+	c.log().Debug("Retrieving the cluster configuration")
+	cm, err := c.manager.GetConfigMap(c.cmd.Context())
+	if err != nil {
+		return err
+	}
+	payload := cm.Data[config.Filename]
+	migrated, applied, err := config.Migrate([]byte(payload))
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Printf("Configuration is up to date, apiVersion %q.\n",
+			config.APIVersion)
+		return nil
+	}
+	for _, m := range applied {
+		fmt.Printf("# Migrating from apiVersion %q to %q, %s.\n",
+			m.From, m.To, m.Description)
+	}
+	cfg, err := config.NewConfigFromBytes(migrated)
+	if err != nil {
+		return err
+	}
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+	c.log().Debug("Verifying installer Helm charts")
+	if err = verifyConfig(c.log(), c.cfs, cfg); err != nil {
+		return err
+	}
+	diff, err := config.Diff("current", payload, config.APIVersion, cfg.String())
+	if err != nil {
+		return err
+	}
+	fmt.Print(diff)
+
+	if c.flags.DryRun {
+		fmt.Printf("[DRY-RUN] Storing the configuration migrated to %q\n",
+			config.APIVersion)
+		return nil
+	}
+	cfg.SetResourceVersion(cm.GetResourceVersion())
+	if err = c.manager.Update(c.cmd.Context(), cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Configuration migrated to apiVersion %q.\n", config.APIVersion)
+	return nil
+}
+
+// runListProfiles lists the configuration profiles shipped with the installer.
+func (c *Config) runListProfiles() error {
+	profiles, err := config.GetProfiles(c.cfs)
+	if err != nil {
+		return err
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Profile\tDescription")
+	for _, p := range profiles {
+		fmt.Fprintf(table, "%s\t%s\n", p.Name, p.Description)
+	}
+	return table.Flush()
+}
+
 // runGet controls the cluster configuration retrieval process.
 func (c *Config) runGet() error {
 	c.log().Debug("Retrieving the cluster configuration")
@@ -234,6 +646,7 @@ func (c *Config) runGet() error {
 		}
 		return err
 	}
+	warnMigrations(cfg)
 	c.log().Debug("Formatting the configuration as string")
 	fmt.Print(cfg.String())
 	return nil
@@ -252,6 +665,18 @@ func (c *Config) Run() error {
 		if err = c.runDelete(); err != nil {
 			return err
 		}
+	case c.validate:
+		return c.runValidate()
+	case c.history:
+		return c.runHistory()
+	case len(c.diff) > 0:
+		return c.runDiff()
+	case c.rollback > 0:
+		return c.runRollback()
+	case c.migrate:
+		return c.runMigrate()
+	case c.listProfiles:
+		return c.runListProfiles()
 	}
 
 	// The --get flag can take place together with other flags, thus this block
@@ -273,7 +698,7 @@ func NewConfig(
 ) Interface {
 	c := &Config{
 		cmd: &cobra.Command{
-			Use:          "config [flags] [path/to/config.yaml]",
+			Use:          "config [flags] [path/to/config.yaml...]",
 			Short:        "Manages installer's cluster configuration",
 			Long:         configDesc,
 			SilenceUsage: true,
@@ -285,7 +710,13 @@ func NewConfig(
 		manager: config.NewConfigMapManager(kube),
 	}
 
-	c.PersistentFlags(c.cmd.PersistentFlags())
+	c.PersistentFlags(c.cmd.Flags())
 
+	for _, action := range []configEditAction{
+		configSet, configUnset, configEnable, configDisable,
+	} {
+		c.cmd.AddCommand(
+			NewRunner(NewConfigEdit(action, logger, f, cfs, kube)).Cmd())
+	}
 	return c
 }
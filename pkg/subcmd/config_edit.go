@@ -0,0 +1,239 @@
+package subcmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+
+	"github.com/spf13/cobra"
+)
+
+// configEditAction the action editing the cluster configuration.
+type configEditAction string
+
+const (
+	// configSet sets attributes, "path=value" arguments.
+	configSet configEditAction = "set"
+	// configUnset removes attributes, "path" arguments.
+	configUnset configEditAction = "unset"
+	// configEnable enables products, product name arguments.
+	configEnable configEditAction = "enable"
+	// configDisable disables products, product name arguments.
+	configDisable configEditAction = "disable"
+)
+
+// ConfigEdit the "config" subcommands editing the cluster configuration in place.
+type ConfigEdit struct {
+	cmd    *cobra.Command   // cobra command
+	logger *slog.Logger     // application logger
+	flags  *flags.Flags     // global flags
+	cfs    *chartfs.ChartFS // embedded filesystem
+	kube   *k8s.Kube        // kubernetes client
+
+	manager *config.ConfigMapManager // cluster configuration manager
+	cfg     *config.Config           // cluster configuration
+	action  configEditAction         // editing action
+	args    []string                 // action arguments
+	retries int                      // retries on concurrent changes
+}
+
+var _ Interface = &ConfigEdit{}
+
+const configConflictDesc = `
+The configuration is only written back when it didn't change since it was read,
+when changed concurrently the command fails. Use "--retries" to read the
+configuration again and re-apply the changes automatically.
+`
+
+const configPathDesc = `
+The attribute path is relative to the "tssc" object, the products are selected by
+name, or by index, between brackets. E.g.:
+	namespace
+	settings.ci.debug
+	products[Developer Hub].properties.authProvider
+`
+
+const configSetDesc = `
+Sets attributes on the cluster configuration, in place.
+
+The value is parsed as YAML, so "true" is a boolean and "[a, b]" is a list,
+quote the value to set a string instead. The objects on the path are created
+when missing. The YAML comments on the cluster configuration are preserved.
+` + configPathDesc + `
+The configuration is validated, and verified against the installer Helm charts,
+before it's written back to the cluster. E.g.:
+	tssc config set 'products[Developer Hub].properties.authProvider=gitlab'
+`
+
+const configUnsetDesc = `
+Removes attributes from the cluster configuration, in place.
+` + configPathDesc + `
+The configuration is validated, and verified against the installer Helm charts,
+before it's written back to the cluster. E.g.:
+	tssc config unset settings.ci.debug
+`
+
+const configToggleDesc = `
+%s products on the cluster configuration, in place, by product name.
+
+The configuration is validated, and verified against the installer Helm charts,
+before it's written back to the cluster. E.g.:
+	tssc config %s "Trusted Profile Analyzer"
+`
+
+// Cmd exposes the cobra instance.
+func (c *ConfigEdit) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// log returns a decorated logger.
+func (c *ConfigEdit) log() *slog.Logger {
+	return c.flags.LoggerWith(c.logger.With(
+		"action", c.action,
+		"args", c.args,
+	))
+}
+
+// splitAssignment splits the "path=value" argument, the equal sign on a product
+// selector is part of the path.
+func splitAssignment(arg string) (string, string, error) {
+	brackets := false
+	for i, r := range arg {
+		switch r {
+		case '[':
+			brackets = true
+		case ']':
+			brackets = false
+		case '=':
+			if !brackets {
+				return arg[:i], arg[i+1:], nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("invalid argument %q, expected path=value", arg)
+}
+
+// Complete loads the cluster configuration.
+func (c *ConfigEdit) Complete(args []string) error {
+	c.args = args
+	var err error
+	c.cfg, err = bootstrapConfig(c.cmd.Context(), c.kube)
+	return err
+}
+
+// Validate validates the arguments.
+func (c *ConfigEdit) Validate() error {
+	if c.action != configSet {
+		return nil
+	}
+	for _, arg := range c.args {
+		if _, _, err := splitAssignment(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// edit applies the action arguments on the configuration, and verifies it.
+func (c *ConfigEdit) edit(cfg *config.Config) error {
+	for _, arg := range c.args {
+		var err error
+		switch c.action {
+		case configSet:
+			path, value, _ := splitAssignment(arg)
+			c.log().Debug("Setting attribute", "path", path, "value", value)
+			err = cfg.Set(path, value)
+		case configUnset:
+			c.log().Debug("Removing attribute", "path", arg)
+			err = cfg.Unset(arg)
+		case configEnable, configDisable:
+			c.log().Debug("Toggling product", "product", arg)
+			err = cfg.SetProductEnabled(arg, c.action == configEnable)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	c.log().Debug("Verifying installer Helm charts")
+	return verifyConfig(c.log(), c.cfs, cfg)
+}
+
+// Run edits the cluster configuration, validates and writes it back.
+func (c *ConfigEdit) Run() error {
+	if c.flags.DryRun {
+		if err := c.edit(c.cfg); err != nil {
+			return err
+		}
+		c.log().Debug("[DRY-RUN] Only showing the configuration payload")
+		fmt.Printf("[DRY-RUN] Updating the ConfigMap %q/%q\n",
+			c.cfg.Installer.Namespace, config.Name)
+		fmt.Print(c.cfg.String())
+		return nil
+	}
+
+	c.log().Debug("Updating the configuration in the cluster")
+	_, err := c.manager.Modify(c.cmd.Context(), c.cfg, c.retries, c.edit)
+	if errors.Is(err, config.ErrConfigConflict) {
+		return fmt.Errorf("%w; retry the command, or use --retries", err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Configuration updated: %s %s\n",
+		c.action, strings.Join(c.args, ", "))
+	return nil
+}
+
+// NewConfigEdit instantiates the "config" subcommand for the editing action.
+func NewConfigEdit(
+	action configEditAction,
+	logger *slog.Logger,
+	f *flags.Flags,
+	cfs *chartfs.ChartFS,
+	kube *k8s.Kube,
+) Interface {
+	cmd := &cobra.Command{
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+	}
+	switch action {
+	case configSet:
+		cmd.Use = "set <path=value>..."
+		cmd.Short = "Sets attributes on the cluster configuration"
+		cmd.Long = configSetDesc
+	case configUnset:
+		cmd.Use = "unset <path>..."
+		cmd.Short = "Removes attributes from the cluster configuration"
+		cmd.Long = configUnsetDesc
+	case configEnable:
+		cmd.Use = "enable <product>..."
+		cmd.Short = "Enables products on the cluster configuration"
+		cmd.Long = fmt.Sprintf(configToggleDesc, "Enables", action)
+	case configDisable:
+		cmd.Use = "disable <product>..."
+		cmd.Short = "Disables products on the cluster configuration"
+		cmd.Long = fmt.Sprintf(configToggleDesc, "Disables", action)
+	}
+	cmd.Long += configConflictDesc
+	c := &ConfigEdit{
+		cmd:     cmd,
+		logger:  logger.WithGroup("config").WithGroup(string(action)),
+		flags:   f,
+		cfs:     cfs,
+		kube:    kube,
+		manager: config.NewConfigMapManager(kube),
+		action:  action,
+	}
+	cmd.PersistentFlags().IntVar(&c.retries, "retries", 0,
+		"Times to re-apply the changes when the configuration changed concurrently")
+	return c
+}
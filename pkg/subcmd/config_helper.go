@@ -3,11 +3,17 @@ package subcmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
 	"github.com/redhat-appstudio/tssc-cli/pkg/constants"
+	"github.com/redhat-appstudio/tssc-cli/pkg/engine"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+	"github.com/redhat-appstudio/tssc-cli/pkg/installer"
 	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+	"github.com/redhat-appstudio/tssc-cli/pkg/trust"
 )
 
 // bootstrapConfig helper to retrieve the cluster configuration.
@@ -25,6 +31,83 @@ configuration for the target cluster.
 
 	$ %s config --help
 		`, constants.AppName)
+		return nil, err
+	}
+	warnMigrations(cfg)
+	return cfg, nil
+}
+
+// warnMigrations warns about the configuration migrated in memory, not stored
+// in the cluster yet.
+func warnMigrations(cfg *config.Config) {
+	for _, m := range cfg.Migrations() {
+		fmt.Fprintf(os.Stderr,
+			"WARNING: cluster configuration migrated from apiVersion %q to %q, "+
+				"%s. Run \"%s config --migrate\" to store it.\n",
+			m.From, m.To, m.Description, constants.AppName)
+	}
+}
+
+// bootstrapVariables helper to collect the values template context, including
+// the trust settings with the CA bundle informed by "--ca-bundle".
+func bootstrapVariables(
+	ctx context.Context,
+	logger *slog.Logger,
+	f *flags.Flags,
+	kube *k8s.Kube,
+	cfg *config.Config,
+) (*engine.Variables, error) {
+	t, err := trust.NewTrust(ctx, logger, kube, &cfg.Installer, f.CABundlePath)
+	if err != nil {
+		return nil, err
+	}
+	return installer.NewVariables(ctx, kube, &cfg.Installer, t)
+}
+
+// bootstrapHTTPClient helper to create the HTTP client for the integrations,
+// using the cluster-wide proxy and the trusted certificates.
+func bootstrapHTTPClient(
+	ctx context.Context,
+	logger *slog.Logger,
+	f *flags.Flags,
+	kube *k8s.Kube,
+	cfg *config.Config,
+) (*http.Client, error) {
+	t, err := trust.NewTrust(ctx, logger, kube, &cfg.Installer, f.CABundlePath)
+	if err != nil {
+		return nil, err
+	}
+	return t.HTTPClient()
+}
+
+// warnTopology warns when the cluster topology contradicts the "settings.crc"
+// configuration, the check is skipped when the topology can't be detected.
+func warnTopology(
+	ctx context.Context,
+	logger *slog.Logger,
+	kube *k8s.Kube,
+	cfg *config.Config,
+) {
+	if err := kube.Connected(); err != nil {
+		logger.Debug("Skipping the cluster topology check", "err", err)
+		return
+	}
+	topology, err := k8s.GetOpenShiftTopology(ctx, kube)
+	if err != nil {
+		logger.Debug("Unable to detect the cluster topology", "err", err)
+		return
+	}
+	logger.Debug("Cluster topology detected", "topology", topology)
+	crc, _ := cfg.Installer.Settings["crc"].(bool)
+	switch {
+	case topology.CRC && !crc:
+		fmt.Fprintln(os.Stderr, "WARNING: the cluster is an OpenShift Local (CRC) "+
+			"instance, but \"settings.crc\" is disabled.")
+	case topology.SingleNode && !crc:
+		fmt.Fprintln(os.Stderr, "WARNING: the cluster runs on a single node, "+
+			"consider enabling \"settings.crc\" to reduce the resources required.")
+	case !topology.SingleNode && crc:
+		fmt.Fprintln(os.Stderr, "WARNING: \"settings.crc\" is enabled, but the "+
+			"cluster isn't a single-node OpenShift Local (CRC) instance.")
 	}
-	return cfg, err
 }
@@ -1,12 +1,24 @@
 package subcmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"os"
+	"slices"
 	"strings"
+	"sync"
+	"text/tabwriter"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/checkpoint"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/deployer"
+	"github.com/redhat-appstudio/tssc-cli/pkg/engine"
 	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
 	"github.com/redhat-appstudio/tssc-cli/pkg/installer"
 	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
@@ -14,6 +26,7 @@ import (
 	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 // Deploy is the deploy subcommand.
@@ -28,6 +41,18 @@ type Deploy struct {
 	collection         *resolver.Collection // chart collection
 	chartPath          string               // single chart path
 	valuesTemplatePath string               // values template file path
+	maxParallel        int                  // maximum concurrent installations
+	resume             bool                 // resume from the checkpoint
+	plan               bool                 // print the deployment plan only
+	force              bool                 // deploy unchanged charts
+
+	checkpointMgr *checkpoint.Manager    // checkpoint persistence
+	checkpoint    *checkpoint.Checkpoint // deployment progress
+	checkpointMu  sync.Mutex             // serializes checkpoint updates
+	chartDigests  map[string]string      // chart digests by name
+	unfinished    []string               // charts unfinished on the checkpoint
+
+	variables *engine.Variables // values template context, collected once
 }
 
 var _ Interface = &Deploy{}
@@ -50,6 +75,24 @@ The platform configuration is rendered from the values template file
 The installer resources are embedded in the executable, these resources are
 employed by default.
 
+The Helm charts are grouped by dependency level, charts on the same level don't
+depend on each other and are installed concurrently, up to "--max-parallel"
+charts at a time. The output of each chart is shown once its installation is
+done. The deployment stops on the first failure.
+
+The progress of each chart is recorded on the "tssc-deploy-checkpoint" ConfigMap,
+next to the cluster configuration, including the release revision, the chart and
+values digests, and the outcome. When a deployment fails, it can be resumed from
+the first incomplete chart with "--resume", as long as the configuration and the
+charts haven't changed since:
+	tssc deploy --resume
+
+Each Helm release is labeled with the digest of the chart content and rendered
+values. Charts whose digest matches the deployed release are skipped, including
+Helm tests and hooks, unless "--force" is informed. To print the action for each
+chart, "install", "upgrade" or "unchanged", without deploying:
+	tssc deploy --plan
+
 A single chart can be deployed by specifying its path. E.g.:
 	tssc deploy charts/tssc-openshift
 `
@@ -64,6 +107,10 @@ func (d *Deploy) log() *slog.Logger {
 	return d.flags.LoggerWith(d.logger.With(
 		"chart-path", d.chartPath,
 		flags.ValuesTemplateFlag, d.valuesTemplatePath,
+		"max-parallel", d.maxParallel,
+		"resume", d.resume,
+		"plan", d.plan,
+		"force", d.force,
 	))
 }
 
@@ -90,6 +137,19 @@ func (d *Deploy) Complete(args []string) error {
 
 // Validate asserts the requirements to start the deployment are in place.
 func (d *Deploy) Validate() error {
+	if d.maxParallel < 1 {
+		return fmt.Errorf("max-parallel must be at least 1, got %d", d.maxParallel)
+	}
+	if d.resume && d.chartPath != "" {
+		return fmt.Errorf("--resume can't be used to deploy a single chart")
+	}
+	if d.plan && d.resume {
+		return fmt.Errorf("--plan and --resume can't be used together")
+	}
+	// The plan is read-only, the installer namespace isn't required.
+	if d.plan {
+		return nil
+	}
 	return k8s.EnsureOpenShiftProject(
 		d.cmd.Context(),
 		d.log(),
@@ -98,6 +158,283 @@ func (d *Deploy) Validate() error {
 	)
 }
 
+// prepareCheckpoint prepares the deployment checkpoint for the topology. When
+// resuming, the checkpoint recorded in the cluster is loaded and verified against
+// the current configuration and charts, otherwise a new checkpoint is recorded.
+func (d *Deploy) prepareCheckpoint(topology *resolver.Topology) error {
+	d.chartDigests = map[string]string{}
+	for _, dep := range topology.Dependencies() {
+		digest, err := checkpoint.ChartDigest(dep.Chart())
+		if err != nil {
+			return err
+		}
+		d.chartDigests[dep.Name()] = digest
+	}
+	configDigest := checkpoint.ConfigDigest(d.cfg)
+
+	d.checkpointMgr = checkpoint.NewManager(d.kube, d.cfg.Installer.Namespace)
+	if !d.resume {
+		d.checkpoint = checkpoint.NewCheckpoint(configDigest)
+		if d.flags.DryRun {
+			return nil
+		}
+		// While the previous deployment is incomplete its baseline is kept, so
+		// "tssc rollback" still goes back to the last successful deployment.
+		charts := slices.Collect(maps.Keys(d.chartDigests))
+		previous, err := d.checkpointMgr.Get(d.cmd.Context())
+		switch {
+		case err == nil && !previous.Completed(charts):
+			d.log().Debug("Keeping the incomplete deployment baseline")
+			d.checkpoint.Baseline = maps.Clone(previous.Baseline)
+			d.unfinished = previous.Unfinished()
+		case errors.Is(err, checkpoint.ErrCheckpointNotFound),
+			errors.Is(err, checkpoint.ErrIncompleteCheckpoint):
+			d.log().Debug(err.Error())
+		case err != nil:
+			return err
+		}
+		// Recording the release revisions before the deployment starts, these
+		// are the revisions "tssc rollback" goes back to.
+		d.log().Debug("Recording the release revisions baseline")
+		for _, dep := range topology.Dependencies() {
+			if _, exists := d.checkpoint.Baseline[dep.Name()]; exists {
+				continue
+			}
+			i := installer.NewInstaller(
+				d.log(), d.flags, d.kube, &dep, io.Discard, io.Discard)
+			revision, err := i.DeployedRevision()
+			if err != nil {
+				return fmt.Errorf("%s: %w", dep.Name(), err)
+			}
+			d.checkpoint.SetBaseline(dep.Name(), revision)
+		}
+		return d.checkpointMgr.Save(d.cmd.Context(), d.checkpoint)
+	}
+
+	d.log().Debug("Loading the deployment checkpoint")
+	var err error
+	if d.checkpoint, err = d.checkpointMgr.Get(d.cmd.Context()); err != nil {
+		return err
+	}
+	if err = d.checkpoint.Verify(configDigest, d.chartDigests); err != nil {
+		return fmt.Errorf("%w, deploy again without --resume", err)
+	}
+	d.unfinished = d.checkpoint.Unfinished()
+	return nil
+}
+
+// record records the dependency progress on the checkpoint, and persists it in
+// the cluster. Failing to persist the checkpoint doesn't stop the deployment.
+func (d *Deploy) record(
+	dep resolver.Dependency, // dependency deployed
+	i *installer.Installer, // dependency installer
+	outcome checkpoint.Outcome, // deployment outcome
+	err error, // deployment error, if any
+) {
+	if d.checkpoint == nil || d.flags.DryRun {
+		return
+	}
+	entry := checkpoint.Entry{
+		Chart:       dep.Name(),
+		Namespace:   dep.Namespace(),
+		Revision:    i.Revision(),
+		ChartDigest: d.chartDigests[dep.Name()],
+		Outcome:     outcome,
+	}
+	if vals := i.Values(); vals != nil {
+		digest, digestErr := checkpoint.ValuesDigest(vals)
+		if digestErr != nil {
+			d.log().Debug(digestErr.Error())
+		}
+		entry.ValuesDigest = digest
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	d.checkpointMu.Lock()
+	defer d.checkpointMu.Unlock()
+	d.checkpoint.Record(entry)
+	if err = d.checkpointMgr.Save(d.cmd.Context(), d.checkpoint); err != nil {
+		d.log().Warn("Unable to save the deployment checkpoint", "error", err)
+	}
+}
+
+// deployDependency renders the values template and installs the informed
+// dependency, the output is written on the informed writers. The progress is
+// recorded on the deployment checkpoint.
+func (d *Deploy) deployDependency(
+	ctx context.Context, // cancelled when a concurrent installation fails
+	logger *slog.Logger, // dependency logger
+	dep resolver.Dependency, // dependency to install
+	valuesTmpl []byte, // values template payload
+	stdout, stderr io.Writer, // dependency output
+	index int, // deployment index
+	total int, // total of dependencies
+) (err error) {
+	fmt.Fprintf(stdout, "\n\n%s\n", strings.Repeat("#", 60))
+	fmt.Fprintf(
+		stdout,
+		"# [%d/%d] Deploying '%s' in '%s'.\n",
+		index,
+		total,
+		dep.Name(),
+		dep.Namespace(),
+	)
+	fmt.Fprintf(stdout, "%s\n", strings.Repeat("#", 60))
+
+	i := installer.NewInstaller(logger, d.flags, d.kube, &dep, stdout, stderr)
+	d.record(dep, i, checkpoint.InProgress, nil)
+	defer func() {
+		if err != nil {
+			d.record(dep, i, checkpoint.Failed, err)
+		} else {
+			d.record(dep, i, checkpoint.Succeeded, nil)
+		}
+	}()
+
+	if err = i.SetValues(d.variables, string(valuesTmpl)); err != nil {
+		return err
+	}
+	if d.flags.Debug {
+		i.PrintRawValues()
+	}
+
+	if err = i.RenderValues(); err != nil {
+		return err
+	}
+	if d.flags.Debug {
+		i.PrintValues()
+	}
+
+	// The release of a chart which deployment failed, or was interrupted, may
+	// carry the digest while not verified, thus it's always deployed again.
+	if !d.force && !slices.Contains(d.unfinished, dep.Name()) {
+		var action deployer.Action
+		if action, err = i.Plan(); err != nil {
+			return err
+		}
+		if action == deployer.ActionUnchanged {
+			fmt.Fprintf(stdout, "# Skipping '%s', unchanged since revision %d.\n",
+				dep.Name(), i.Revision())
+			fmt.Fprintf(stdout, "%s\n", strings.Repeat("#", 60))
+			return nil
+		}
+	}
+
+	if err = i.Install(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s\n", strings.Repeat("#", 60))
+	return nil
+}
+
+// deployLevel installs the dependencies of a single topology level, at most
+// "--max-parallel" dependencies are installed concurrently. When running
+// concurrently each dependency output is buffered, and printed as a whole when
+// its installation is done, so the output doesn't interleave. The first failure
+// prevents the remaining dependencies of the level from starting.
+func (d *Deploy) deployLevel(
+	level resolver.Dependencies, // dependencies on the same level
+	valuesTmpl []byte, // values template payload
+	index int, // index of the first dependency in the level
+	total int, // total of dependencies
+) error {
+	// Sequential installation writes directly on the standard output.
+	if d.maxParallel == 1 || len(level) == 1 {
+		for i, dep := range level {
+			if err := d.deployDependency(
+				d.cmd.Context(), d.log(), dep, valuesTmpl, os.Stdout, os.Stderr, index+i, total,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var mu sync.Mutex // serializes the output of concurrent installations
+	g, ctx := errgroup.WithContext(d.cmd.Context())
+	g.SetLimit(d.maxParallel)
+	for i, dep := range level {
+		g.Go(func() error {
+			// A previous dependency has failed, the remaining are skipped.
+			if ctx.Err() != nil {
+				return nil
+			}
+			var buf bytes.Buffer
+			logger := d.flags.LoggerWith(
+				d.flags.GetLogger(&buf).WithGroup("deploy").With(
+					flags.ValuesTemplateFlag, d.valuesTemplatePath,
+				),
+			)
+			err := d.deployDependency(
+				ctx, logger, dep, valuesTmpl, &buf, &buf, index+i, total)
+
+			mu.Lock()
+			defer mu.Unlock()
+			_, _ = buf.WriteTo(os.Stdout)
+			if err != nil {
+				return fmt.Errorf("%s: %w", dep.Name(), err)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// printPlan renders the values of each dependency and prints the action the
+// deployment performs on its release.
+func (d *Deploy) printPlan(
+	levels []resolver.Dependencies, // dependencies to deploy
+	valuesTmpl []byte, // values template payload
+) error {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Chart\tNamespace\tAction")
+	for _, level := range levels {
+		for _, dep := range level {
+			i := installer.NewInstaller(
+				d.log(), d.flags, d.kube, &dep, io.Discard, os.Stderr)
+			err := i.SetValues(d.variables, string(valuesTmpl))
+			if err != nil {
+				return fmt.Errorf("%s: %w", dep.Name(), err)
+			}
+			if err = i.RenderValues(); err != nil {
+				return fmt.Errorf("%s: %w", dep.Name(), err)
+			}
+			action, err := i.Plan()
+			if err != nil {
+				return fmt.Errorf("%s: %w", dep.Name(), err)
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\n",
+				dep.Name(), dep.Namespace(), action)
+		}
+	}
+	return table.Flush()
+}
+
+// pendingLevels filters out the dependencies deployed successfully according to
+// the checkpoint, empty levels are removed.
+func (d *Deploy) pendingLevels(
+	levels []resolver.Dependencies,
+) []resolver.Dependencies {
+	pending := []resolver.Dependencies{}
+	for _, level := range levels {
+		remaining := resolver.Dependencies{}
+		for _, dep := range level {
+			if d.checkpoint.Succeeded(dep.Name()) {
+				fmt.Printf("# Skipping '%s', already deployed (checkpoint).\n",
+					dep.Name())
+				continue
+			}
+			remaining = append(remaining, dep)
+		}
+		if len(remaining) > 0 {
+			pending = append(pending, remaining)
+		}
+	}
+	return pending
+}
+
 // Run deploys the enabled dependencies listed on the configuration.
 func (d *Deploy) Run() error {
 	printer.Disclaimer()
@@ -115,10 +452,10 @@ func (d *Deploy) Run() error {
 		return err
 	}
 
-	var deps resolver.Dependencies
+	var levels []resolver.Dependencies
 	if d.chartPath == "" {
 		d.log().Debug("Installing all dependencies...")
-		deps = topology.Dependencies()
+		levels = topology.Levels()
 	} else {
 		d.log().Debug("Installing a single Helm chart...")
 		hc, err := d.cfs.GetChartFiles(d.chartPath)
@@ -129,41 +466,45 @@ func (d *Deploy) Run() error {
 		if err != nil {
 			return err
 		}
-		deps = append(deps, *dep)
+		levels = append(levels, resolver.Dependencies{*dep})
 	}
 
-	for index, dep := range deps {
-		fmt.Printf("\n\n%s\n", strings.Repeat("#", 60))
-		fmt.Printf(
-			"# [%d/%d] Deploying '%s' in '%s'.\n",
-			index+1,
-			len(deps),
-			dep.Name(),
-			dep.Namespace(),
-		)
-		fmt.Printf("%s\n", strings.Repeat("#", 60))
+	// The cluster facts are collected once, and shared by all dependencies.
+	d.log().Debug("Preparing values template context")
+	if d.variables, err = bootstrapVariables(
+		d.cmd.Context(), d.log(), d.flags, d.kube, d.cfg,
+	); err != nil {
+		return err
+	}
 
-		i := installer.NewInstaller(d.log(), d.flags, d.kube, &dep)
+	if d.plan {
+		return d.printPlan(levels, valuesTmpl)
+	}
 
-		err := i.SetValues(d.cmd.Context(), &d.cfg.Installer, string(valuesTmpl))
-		if err != nil {
+	// Full deployments are recorded on the checkpoint, when resuming the charts
+	// already deployed are skipped.
+	if d.chartPath == "" {
+		if err = d.prepareCheckpoint(topology); err != nil {
 			return err
 		}
-		if d.flags.Debug {
-			i.PrintRawValues()
-		}
+	}
+	if d.resume {
+		levels = d.pendingLevels(levels)
+	}
 
-		if err := i.RenderValues(); err != nil {
-			return err
-		}
-		if d.flags.Debug {
-			i.PrintValues()
-		}
+	total := 0
+	for _, level := range levels {
+		total += len(level)
+	}
 
-		if err = i.Install(d.cmd.Context()); err != nil {
+	index := 1
+	for _, level := range levels {
+		if err = d.deployLevel(level, valuesTmpl, index, total); err != nil {
 			return err
 		}
-		// Cleaning up temporary resources.
+		index += len(level)
+		// Cleaning up temporary resources, only after the whole level is done
+		// since the resources are shared between concurrent installations.
 		if err = k8s.RetryDeleteResources(
 			d.cmd.Context(),
 			d.kube,
@@ -171,7 +512,6 @@ func (d *Deploy) Run() error {
 		); err != nil {
 			d.log().Debug(err.Error())
 		}
-		fmt.Printf("%s\n", strings.Repeat("#", 60))
 	}
 
 	fmt.Printf("Deployment complete!\n")
@@ -192,12 +532,22 @@ func NewDeploy(
 			Long:         deployDesc,
 			SilenceUsage: true,
 		},
-		logger:    logger.WithGroup("deploy"),
-		flags:     f,
-		cfs:       cfs,
-		kube:      kube,
-		chartPath: "",
+		logger:      logger.WithGroup("deploy"),
+		flags:       f,
+		cfs:         cfs,
+		kube:        kube,
+		chartPath:   "",
+		maxParallel: 1,
 	}
-	flags.SetValuesTmplFlag(d.cmd.PersistentFlags(), &d.valuesTemplatePath)
+	p := d.cmd.PersistentFlags()
+	flags.SetValuesTmplFlag(p, &d.valuesTemplatePath)
+	p.IntVar(&d.maxParallel, "max-parallel", d.maxParallel,
+		"Maximum number of charts installed concurrently")
+	p.BoolVar(&d.resume, "resume", d.resume,
+		"Resume the deployment from the first incomplete chart")
+	p.BoolVar(&d.plan, "plan", d.plan,
+		"Print the action for each chart, without deploying")
+	p.BoolVar(&d.force, "force", d.force,
+		"Deploy the charts even when unchanged")
 	return d
 }
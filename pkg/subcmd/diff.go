@@ -0,0 +1,198 @@
+package subcmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/diff"
+	"github.com/redhat-appstudio/tssc-cli/pkg/engine"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+	"github.com/redhat-appstudio/tssc-cli/pkg/installer"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
+
+	"github.com/spf13/cobra"
+)
+
+// Diff is the diff subcommand, shows the changes a deployment would apply on the
+// Helm releases.
+type Diff struct {
+	cmd    *cobra.Command   // cobra command
+	logger *slog.Logger     // application logger
+	flags  *flags.Flags     // global flags
+	cfg    *config.Config   // installer configuration
+	cfs    *chartfs.ChartFS // embedded filesystem
+	kube   *k8s.Kube        // kubernetes client
+
+	collection         *resolver.Collection // chart collection
+	chartPath          string               // single chart path
+	valuesTemplatePath string               // values template file path
+}
+
+var _ Interface = &Diff{}
+
+const diffDesc = `
+Shows the changes "tssc deploy" would apply on the cluster, without changes.
+
+Each Helm chart in the topology is rendered with the values template and the
+cluster configuration, the same way the deployment does, and compared with the
+manifest of the deployed Helm release. The differences are shown per Kubernetes
+resource as a unified diff, charts not deployed yet show all resources as added.
+
+The Secret "data" and "stringData" values are always redacted, changed values are
+marked as "<redacted, changed>".
+
+A single chart can be compared by specifying its path. E.g.:
+	tssc diff charts/tssc-dh
+`
+
+// Cmd exposes the cobra instance.
+func (d *Diff) Cmd() *cobra.Command {
+	return d.cmd
+}
+
+// log logger with contextual information.
+func (d *Diff) log() *slog.Logger {
+	return d.flags.LoggerWith(d.logger.With(
+		"chart-path", d.chartPath,
+		flags.ValuesTemplateFlag, d.valuesTemplatePath,
+	))
+}
+
+// Complete loads the charts and the cluster configuration.
+func (d *Diff) Complete(args []string) error {
+	// Load all charts from the embedded filesystem, or from a local directory.
+	charts, err := d.cfs.GetAllCharts()
+	if err != nil {
+		return err
+	}
+	// Create a new chart collection from the loaded charts.
+	if d.collection, err = resolver.NewCollection(charts); err != nil {
+		return err
+	}
+	// Load the installer configuration from the cluster.
+	if d.cfg, err = bootstrapConfig(d.cmd.Context(), d.kube); err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		d.chartPath = args[0]
+	}
+	return nil
+}
+
+// Validate validates the command.
+func (d *Diff) Validate() error {
+	return nil
+}
+
+// dependencies returns the dependencies to compare, in topology order.
+func (d *Diff) dependencies() (resolver.Dependencies, error) {
+	topology := resolver.NewTopology()
+	if err := resolver.NewResolver(d.cfg, d.collection, topology).
+		Resolve(); err != nil {
+		return nil, err
+	}
+	if d.chartPath == "" {
+		return topology.Dependencies(), nil
+	}
+	hc, err := d.cfs.GetChartFiles(d.chartPath)
+	if err != nil {
+		return nil, err
+	}
+	dep, err := topology.GetDependency(hc.Name())
+	if err != nil {
+		return nil, err
+	}
+	return resolver.Dependencies{*dep}, nil
+}
+
+// diffDependency renders the dependency and prints the differences with the
+// deployed release.
+func (d *Diff) diffDependency(
+	dep resolver.Dependency, // dependency to compare
+	variables *engine.Variables, // values template context
+	valuesTmpl []byte, // values template payload
+) error {
+	i := installer.NewInstaller(
+		d.log(), d.flags, d.kube, &dep, io.Discard, os.Stderr)
+	if err := i.SetValues(variables, string(valuesTmpl)); err != nil {
+		return err
+	}
+	if err := i.RenderValues(); err != nil {
+		return err
+	}
+	deployed, rendered, err := i.Manifests()
+	if err != nil {
+		return err
+	}
+	diffs, err := diff.Manifests(deployed, rendered)
+	if err != nil {
+		return err
+	}
+	diff.Print(os.Stdout, diffs)
+	return nil
+}
+
+// Run renders each dependency and shows the differences with the deployed
+// releases.
+func (d *Diff) Run() error {
+	d.log().Debug("Reading values template file")
+	valuesTmpl, err := d.cfs.ReadFile(d.valuesTemplatePath)
+	if err != nil {
+		return fmt.Errorf("failed to read values template file: %w", err)
+	}
+
+	d.log().Debug("Resolving dependencies...")
+	deps, err := d.dependencies()
+	if err != nil {
+		return err
+	}
+
+	d.log().Debug("Preparing values template context")
+	variables, err := bootstrapVariables(
+		d.cmd.Context(), d.log(), d.flags, d.kube, d.cfg)
+	if err != nil {
+		return err
+	}
+
+	for index, dep := range deps {
+		fmt.Printf("\n%s\n", strings.Repeat("#", 60))
+		fmt.Printf("# [%d/%d] Comparing '%s' in '%s'.\n",
+			index+1, len(deps), dep.Name(), dep.Namespace())
+		fmt.Printf("%s\n", strings.Repeat("#", 60))
+
+		if err = d.diffDependency(dep, variables, valuesTmpl); err != nil {
+			return fmt.Errorf("%s: %w", dep.Name(), err)
+		}
+	}
+	return nil
+}
+
+// NewDiff instantiates the diff subcommand.
+func NewDiff(
+	logger *slog.Logger,
+	f *flags.Flags,
+	cfs *chartfs.ChartFS,
+	kube *k8s.Kube,
+) Interface {
+	d := &Diff{
+		cmd: &cobra.Command{
+			Use:          "diff [chart]",
+			Short:        "Shows the changes a deployment would apply",
+			Long:         diffDesc,
+			Args:         cobra.MaximumNArgs(1),
+			SilenceUsage: true,
+		},
+		logger: logger.WithGroup("diff"),
+		flags:  f,
+		cfs:    cfs,
+		kube:   kube,
+	}
+	flags.SetValuesTmplFlag(d.cmd.PersistentFlags(), &d.valuesTemplatePath)
+	return d
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
 	"github.com/redhat-appstudio/tssc-cli/pkg/constants"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
 	"github.com/redhat-appstudio/tssc-cli/pkg/githubapp"
 	"github.com/redhat-appstudio/tssc-cli/pkg/integrations"
 	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
@@ -18,6 +19,7 @@ import (
 type IntegrationGitHubApp struct {
 	cmd    *cobra.Command // cobra command
 	logger *slog.Logger   // application logger
+	flags  *flags.Flags   // global flags
 	cfg    *config.Config // installer configuration
 	kube   *k8s.Kube      // kubernetes client
 
@@ -55,6 +57,12 @@ func (d *IntegrationGitHubApp) Complete(args []string) error {
 	if err != nil {
 		return err
 	}
+	httpClient, err := bootstrapHTTPClient(
+		d.cmd.Context(), d.logger, d.flags, d.kube, d.cfg)
+	if err != nil {
+		return err
+	}
+	d.gitHubIntegration.SetHTTPClient(httpClient)
 
 	if d.create && d.update {
 		return fmt.Errorf("cannot create and update at the same time")
@@ -104,6 +112,7 @@ func (d *IntegrationGitHubApp) Run() error {
 // github-app", which manages the TSSC integration with a GitHub App.
 func NewIntegrationGitHubApp(
 	logger *slog.Logger,
+	f *flags.Flags,
 	kube *k8s.Kube,
 ) *IntegrationGitHubApp {
 	gitHubApp := githubapp.NewGitHubApp(logger)
@@ -118,6 +127,7 @@ func NewIntegrationGitHubApp(
 		},
 
 		logger: logger,
+		flags:  f,
 		kube:   kube,
 
 		gitHubIntegration: gitHubIntegration,
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
 	"github.com/redhat-appstudio/tssc-cli/pkg/integrations"
 	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
 
@@ -15,6 +16,7 @@ import (
 type IntegrationGitLab struct {
 	cmd    *cobra.Command // cobra command
 	logger *slog.Logger   // application logger
+	flags  *flags.Flags   // global flags
 	cfg    *config.Config // installer configuration
 	kube   *k8s.Kube      // kubernetes client
 
@@ -36,11 +38,20 @@ func (d *IntegrationGitLab) Cmd() *cobra.Command {
 	return d.cmd
 }
 
-// Complete is a no-op in this case.
+// Complete loads the configuration, and prepares the HTTP client with the proxy
+// and trusted certificates settings.
 func (d *IntegrationGitLab) Complete(args []string) error {
 	var err error
-	d.cfg, err = bootstrapConfig(d.cmd.Context(), d.kube)
-	return err
+	if d.cfg, err = bootstrapConfig(d.cmd.Context(), d.kube); err != nil {
+		return err
+	}
+	httpClient, err := bootstrapHTTPClient(
+		d.cmd.Context(), d.logger, d.flags, d.kube, d.cfg)
+	if err != nil {
+		return err
+	}
+	d.gitlabIntegration.SetHTTPClient(httpClient)
+	return nil
 }
 
 // Validate checks if the required configuration is set.
@@ -61,6 +72,7 @@ func (d *IntegrationGitLab) Run() error {
 // responsible to manage the TSSC integrations with the GitLab service.
 func NewIntegrationGitLab(
 	logger *slog.Logger,
+	f *flags.Flags,
 	kube *k8s.Kube,
 ) *IntegrationGitLab {
 	gitlabIntegration := integrations.NewGitLabIntegration(logger, kube)
@@ -74,6 +86,7 @@ func NewIntegrationGitLab(
 		},
 
 		logger: logger,
+		flags:  f,
 		kube:   kube,
 
 		gitlabIntegration: gitlabIntegration,
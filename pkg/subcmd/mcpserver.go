@@ -4,6 +4,7 @@ import (
 	"io"
 	"log/slog"
 
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
 	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
 	"github.com/redhat-appstudio/tssc-cli/pkg/installer"
@@ -16,10 +17,11 @@ import (
 
 // MCPServer is a subcommand for starting the MCP server.
 type MCPServer struct {
-	cmd    *cobra.Command // cobra command
-	logger *slog.Logger   // application logger
-	flags  *flags.Flags   // global flags
-	kube   *k8s.Kube      // kubernetes client
+	cmd    *cobra.Command   // cobra command
+	logger *slog.Logger     // application logger
+	flags  *flags.Flags     // global flags
+	cfs    *chartfs.ChartFS // embedded filesystem
+	kube   *k8s.Kube        // kubernetes client
 
 	image string // installer's container image
 }
@@ -58,12 +60,12 @@ func (m *MCPServer) Validate() error {
 // Run starts the MCP server.
 func (m *MCPServer) Run() error {
 	cm := config.NewConfigMapManager(m.kube)
-	cfgTools, err := mcptools.NewConfigTools(m.logger, m.kube, cm)
+	cfgTools, err := mcptools.NewConfigTools(m.logger, m.cfs, m.kube, cm)
 	if err != nil {
 		return err
 	}
 
-	integrationCmd := NewIntegration(m.logger, m.kube)
+	integrationCmd := NewIntegration(m.logger, m.flags, m.kube)
 	integrationTools := mcptools.NewIntegrationTools(integrationCmd)
 	deployTools := mcptools.NewDeployTools(cm, installer.NewJob(m.kube), m.image)
 
@@ -73,7 +75,11 @@ func (m *MCPServer) Run() error {
 }
 
 // NewMCPServer creates a new MCPServer instance.
-func NewMCPServer(f *flags.Flags, kube *k8s.Kube) *MCPServer {
+func NewMCPServer(
+	f *flags.Flags,
+	cfs *chartfs.ChartFS,
+	kube *k8s.Kube,
+) *MCPServer {
 	m := &MCPServer{
 		cmd: &cobra.Command{
 			Use:   "mcp-server",
@@ -84,6 +90,7 @@ func NewMCPServer(f *flags.Flags, kube *k8s.Kube) *MCPServer {
 		// to the console, for the time being it will be discarded.
 		logger: f.GetLogger(io.Discard),
 		flags:  f,
+		cfs:    cfs,
 		kube:   kube,
 	}
 	m.PersistentFlags(m.cmd)
@@ -0,0 +1,281 @@
+package subcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/checkpoint"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+	"github.com/redhat-appstudio/tssc-cli/pkg/installer"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+	"github.com/redhat-appstudio/tssc-cli/pkg/printer"
+	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
+
+	"github.com/spf13/cobra"
+)
+
+// Rollback is the rollback subcommand, rolls the Helm releases touched by the
+// last deployment back to the revisions recorded before it started.
+type Rollback struct {
+	cmd    *cobra.Command   // cobra command
+	logger *slog.Logger     // application logger
+	flags  *flags.Flags     // global flags
+	cfg    *config.Config   // installer configuration
+	cfs    *chartfs.ChartFS // embedded filesystem
+	kube   *k8s.Kube        // kubernetes client
+
+	collection *resolver.Collection // chart collection
+	chart      string               // single chart to rollback, and dependents
+}
+
+var _ Interface = &Rollback{}
+
+const rollbackDesc = `
+Rolls back the TSSC platform components to the state before the last deployment.
+
+Every "tssc deploy" records the revision of each Helm release before it starts,
+on the deployment checkpoint. The releases touched by the deployment are rolled
+back to those revisions in reverse topology order, a chart is only rolled back
+after all the charts depending on it.
+
+Releases installed for the first time by the deployment have no previous revision
+and are skipped, use "tssc uninstall" to remove them.
+
+A single chart, and the charts depending on it, can be rolled back by informing
+the chart name. E.g.:
+	tssc rollback tssc-dh
+
+Use "--dry-run" to simulate the rollback, without changes.
+`
+
+// rollbackResult the rollback outcome of a single chart.
+type rollbackResult struct {
+	dep    resolver.Dependency // chart rolled back
+	from   int                 // release revision before the rollback
+	to     int                 // baseline release revision
+	result string              // rollback result description
+}
+
+// Cmd exposes the cobra instance.
+func (r *Rollback) Cmd() *cobra.Command {
+	return r.cmd
+}
+
+// log logger with contextual information.
+func (r *Rollback) log() *slog.Logger {
+	return r.flags.LoggerWith(r.logger.With("chart", r.chart))
+}
+
+// Complete loads the charts and the cluster configuration.
+func (r *Rollback) Complete(args []string) error {
+	if len(args) == 1 {
+		r.chart = args[0]
+	}
+	// Load all charts from the embedded filesystem, or from a local directory.
+	charts, err := r.cfs.GetAllCharts()
+	if err != nil {
+		return err
+	}
+	// Create a new chart collection from the loaded charts.
+	if r.collection, err = resolver.NewCollection(charts); err != nil {
+		return err
+	}
+	// Load the installer configuration from the cluster.
+	if r.cfg, err = bootstrapConfig(r.cmd.Context(), r.kube); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate asserts the informed chart exists in the collection.
+func (r *Rollback) Validate() error {
+	if r.chart == "" {
+		return nil
+	}
+	_, err := r.collection.Get(r.chart)
+	return err
+}
+
+// dependencies returns the dependencies touched by the last deployment, in
+// reverse topology order.
+func (r *Rollback) dependencies(
+	cp *checkpoint.Checkpoint,
+) (resolver.Dependencies, error) {
+	topology := resolver.NewTopology()
+	if err := resolver.NewResolver(r.cfg, r.collection, topology).
+		Resolve(); err != nil {
+		return nil, err
+	}
+
+	deps := slices.Clone(topology.Dependencies())
+	if r.chart != "" {
+		dep, err := topology.GetDependency(r.chart)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(resolver.Dependencies{*dep}, topology.Dependents(r.chart)...)
+	}
+	deps = slices.DeleteFunc(deps, func(d resolver.Dependency) bool {
+		return cp.Get(d.Name()) == nil
+	})
+	slices.Reverse(deps)
+	return deps, nil
+}
+
+// rollback rolls the dependency back to the baseline revision, returns the
+// rollback result, and the error when the rollback fails.
+func (r *Rollback) rollback(
+	cp *checkpoint.Checkpoint,
+	dep resolver.Dependency,
+) (*rollbackResult, error) {
+	res := &rollbackResult{dep: dep}
+	baseline, exists := cp.Baseline[dep.Name()]
+	if !exists {
+		res.result = "skipped, no baseline revision recorded"
+		return res, nil
+	}
+	res.to = baseline
+
+	i := installer.NewInstaller(
+		r.log(), r.flags, r.kube, &dep, os.Stdout, os.Stderr)
+	var err error
+	if res.from, err = i.DeployedRevision(); err != nil {
+		res.result = "failed"
+		return res, err
+	}
+	switch {
+	case baseline == 0:
+		res.result = "skipped, installed by the deployment"
+		return res, nil
+	case res.from == 0:
+		res.result = "skipped, release not found"
+		return res, nil
+	case res.from == baseline:
+		res.result = "unchanged"
+		return res, nil
+	}
+
+	if err = i.Rollback(baseline); err != nil {
+		res.result = "failed"
+		return res, err
+	}
+	res.result = "rolled back"
+	entry := *cp.Get(dep.Name())
+	entry.Revision = baseline
+	entry.Outcome = checkpoint.RolledBack
+	entry.Error = ""
+	cp.Record(entry)
+	return res, nil
+}
+
+// printResults prints the rollback result of each chart.
+func (r *Rollback) printResults(w io.Writer, results []*rollbackResult) {
+	revision := func(rev int) string {
+		if rev == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%d", rev)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Chart\tNamespace\tFrom\tTo\tResult")
+	for _, res := range results {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n",
+			res.dep.Name(),
+			res.dep.Namespace(),
+			revision(res.from),
+			revision(res.to),
+			res.result,
+		)
+	}
+	_ = table.Flush()
+}
+
+// Run rolls the Helm releases back in reverse topology order, stops on the first
+// failure and reports the result of each chart.
+func (r *Rollback) Run() error {
+	printer.Disclaimer()
+
+	mgr := checkpoint.NewManager(r.kube, r.cfg.Installer.Namespace)
+	cp, err := mgr.Get(r.cmd.Context())
+	if err != nil {
+		return fmt.Errorf("%w, no deployment to rollback", err)
+	}
+
+	r.log().Debug("Resolving dependencies...")
+	deps, err := r.dependencies(cp)
+	if err != nil {
+		return err
+	}
+
+	results := []*rollbackResult{}
+	var rollbackErr error
+	for index, dep := range deps {
+		if rollbackErr != nil {
+			results = append(results,
+				&rollbackResult{dep: dep, result: "not attempted"})
+			continue
+		}
+
+		fmt.Printf("\n\n%s\n", strings.Repeat("#", 60))
+		fmt.Printf("# [%d/%d] Rolling back '%s' on '%s'.\n",
+			index+1, len(deps), dep.Name(), dep.Namespace())
+		fmt.Printf("%s\n", strings.Repeat("#", 60))
+
+		res, err := r.rollback(cp, dep)
+		results = append(results, res)
+		if err != nil {
+			rollbackErr = fmt.Errorf("%s: %w", dep.Name(), err)
+		}
+	}
+
+	// Charts rolled back are no longer considered deployed, "deploy --resume"
+	// installs them again.
+	var saveErr error
+	if !r.flags.DryRun {
+		if err = mgr.Save(r.cmd.Context(), cp); err != nil {
+			saveErr = fmt.Errorf("failed to record the rollback on the "+
+				"checkpoint, \"deploy --resume\" may skip the charts rolled "+
+				"back: %w", err)
+		}
+	}
+
+	fmt.Printf("\n")
+	r.printResults(os.Stdout, results)
+	if err = errors.Join(rollbackErr, saveErr); err != nil {
+		return err
+	}
+	fmt.Printf("\nRollback complete!\n")
+	return nil
+}
+
+// NewRollback instantiates the rollback subcommand.
+func NewRollback(
+	logger *slog.Logger,
+	f *flags.Flags,
+	cfs *chartfs.ChartFS,
+	kube *k8s.Kube,
+) Interface {
+	r := &Rollback{
+		cmd: &cobra.Command{
+			Use:          "rollback [chart]",
+			Short:        "Rollback TSSC platform components to the previous revisions",
+			Long:         rollbackDesc,
+			Args:         cobra.MaximumNArgs(1),
+			SilenceUsage: true,
+		},
+		logger: logger.WithGroup("rollback"),
+		flags:  f,
+		cfs:    cfs,
+		kube:   kube,
+	}
+	return r
+}
@@ -0,0 +1,186 @@
+package subcmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
+	"github.com/redhat-appstudio/tssc-cli/pkg/status"
+
+	"github.com/spf13/cobra"
+)
+
+// Status is the status subcommand, reports the Helm release status of each
+// dependency in the topology.
+type Status struct {
+	cmd    *cobra.Command   // cobra command
+	logger *slog.Logger     // application logger
+	flags  *flags.Flags     // global flags
+	cfg    *config.Config   // installer configuration
+	cfs    *chartfs.ChartFS // embedded filesystem
+	kube   *k8s.Kube        // kubernetes client
+
+	collection *resolver.Collection // chart collection
+	output     string               // output format
+	watch      bool                 // watch mode, reports continuously
+	interval   time.Duration        // watch mode interval
+}
+
+var _ Interface = &Status{}
+
+const statusDesc = `
+Reports the Helm release status of each dependency in the topology, resolved from
+the cluster configuration. By default, it will output a table with the following
+columns:
+
+  - Chart: the name of the Helm chart, also the release name.
+  - Namespace: the OpenShift namespace where the chart is installed.
+  - Status: the latest Helm release status, or "missing".
+  - Revision: the latest Helm release revision.
+  - Version: the chart version of the latest release.
+  - Last Deployed: when the latest release was deployed.
+  - Notes: missing, failed or pending releases, and when the chart embedded in
+    the installer is newer than the deployed.
+
+Use "--output=json" ("-o") for a machine-readable report, and "--watch" ("-w") to
+report the status continuously, on every "--interval". For instance:
+
+  $ tssc status --watch --interval=30s
+`
+
+// statusOutputJSON the JSON output format.
+const statusOutputJSON = "json"
+
+// Cmd exposes the cobra instance.
+func (s *Status) Cmd() *cobra.Command {
+	return s.cmd
+}
+
+// Complete loads the charts and the cluster configuration.
+func (s *Status) Complete(_ []string) error {
+	// Load all charts from the embedded filesystem, or from a local directory.
+	charts, err := s.cfs.GetAllCharts()
+	if err != nil {
+		return err
+	}
+	// Create a new chart collection from the loaded charts.
+	if s.collection, err = resolver.NewCollection(charts); err != nil {
+		return err
+	}
+	// Load the installer configuration from the cluster.
+	if s.cfg, err = bootstrapConfig(s.cmd.Context(), s.kube); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate asserts the output format and watch interval.
+func (s *Status) Validate() error {
+	switch s.output {
+	case string(resolver.TableOutput), statusOutputJSON:
+	default:
+		return fmt.Errorf("%w: %q, use one of: [%s %s]",
+			resolver.ErrUnsupportedOutputFormat,
+			s.output,
+			resolver.TableOutput,
+			statusOutputJSON,
+		)
+	}
+	if s.interval <= 0 {
+		return fmt.Errorf("invalid --interval %q, must be positive", s.interval)
+	}
+	return nil
+}
+
+// report collects and prints the status report.
+func (s *Status) report(deps resolver.Dependencies) error {
+	report, err := status.Collect(s.logger, s.flags, s.kube, deps)
+	if err != nil {
+		return err
+	}
+	if s.output == statusOutputJSON {
+		return report.PrintJSON(os.Stdout)
+	}
+	if s.watch {
+		// Clearing the terminal before printing the table again.
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("Every %s: %s\n\n",
+			s.interval, report.CollectedAt.Local().Format(time.DateTime))
+	}
+	report.Print(os.Stdout)
+	return nil
+}
+
+// watchReport prints the status report, or the error collecting it.
+func (s *Status) watchReport(deps resolver.Dependencies) {
+	if err := s.report(deps); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s, trying again in %s\n",
+			time.Now().Format(time.DateTime), err, s.interval)
+	}
+}
+
+// Run resolves the topology and reports the release status, continuously when
+// watching.
+func (s *Status) Run() error {
+	topology := resolver.NewTopology()
+	if err := resolver.NewResolver(s.cfg, s.collection, topology).
+		Resolve(); err != nil {
+		return err
+	}
+	deps := topology.Dependencies()
+
+	if !s.watch {
+		return s.report(deps)
+	}
+	// When watching, errors are likely transient, reported and tried again on
+	// the next interval.
+	s.watchReport(deps)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.cmd.Context().Done():
+			return nil
+		case <-ticker.C:
+			s.watchReport(deps)
+		}
+	}
+}
+
+// NewStatus instantiates the status subcommand.
+func NewStatus(
+	logger *slog.Logger,
+	f *flags.Flags,
+	cfs *chartfs.ChartFS,
+	kube *k8s.Kube,
+) Interface {
+	s := &Status{
+		cmd: &cobra.Command{
+			Use:          "status",
+			Short:        "Reports the TSSC platform components status",
+			Long:         statusDesc,
+			SilenceUsage: true,
+		},
+		logger:   logger.WithGroup("status"),
+		flags:    f,
+		cfs:      cfs,
+		kube:     kube,
+		output:   string(resolver.TableOutput),
+		interval: 10 * time.Second,
+	}
+	p := s.cmd.PersistentFlags()
+	p.StringVarP(&s.output, "output", "o", s.output,
+		fmt.Sprintf("Output format, one of: [%s %s]",
+			resolver.TableOutput, statusOutputJSON))
+	p.BoolVarP(&s.watch, "watch", "w", s.watch,
+		"Report the status continuously")
+	p.DurationVar(&s.interval, "interval", s.interval,
+		"Interval between reports, used with --watch")
+	return s
+}
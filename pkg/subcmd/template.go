@@ -3,6 +3,7 @@ package subcmd
 import (
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
@@ -111,14 +112,16 @@ func (t *Template) Run() error {
 		return fmt.Errorf("failed to read values template file: %w", err)
 	}
 
-	i := installer.NewInstaller(t.logger, t.flags, t.kube, &t.dep)
+	i := installer.NewInstaller(
+		t.logger, t.flags, t.kube, &t.dep, os.Stdout, os.Stderr)
 
-	// Setting values and loading cluster's information.
-	if err = i.SetValues(
-		t.cmd.Context(),
-		&t.cfg.Installer,
-		string(valuesTmplPayload),
-	); err != nil {
+	// Loading cluster's information and setting values.
+	variables, err := bootstrapVariables(
+		t.cmd.Context(), t.logger, t.flags, t.kube, t.cfg)
+	if err != nil {
+		return err
+	}
+	if err = i.SetValues(variables, string(valuesTmplPayload)); err != nil {
 		return err
 	}
 
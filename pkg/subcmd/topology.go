@@ -1,8 +1,10 @@
 package subcmd
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 
 	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
 	"github.com/redhat-appstudio/tssc-cli/pkg/config"
@@ -22,19 +24,46 @@ type Topology struct {
 
 	collection *resolver.Collection // chart collection
 	cfg        *config.Config       // installer configuration
+
+	output     string // output format
+	explain    string // chart name to explain
+	lint       bool   // lint charts and configuration, offline
+	configPath string // local configuration file, used for linting
 }
 
 var _ Interface = &Topology{}
 
 const topologyDesc = `
 Report the dependency topology of the installer based on the cluster configuration
-and Helm charts. It will output a table with the following columns: 
+and Helm charts. By default, it will output a table with the following columns: 
 
   - Index: the index of the chart in the dependency graph.
   - Dependency: the name of the Helm chart.
   - Namespace: the OpenShift namespace where the chart is installed.
   - Product: the name of the product that the chart is associated with.
   - Depends-On: comma-separated list of charts that this chart depends on.
+
+The output format is chosen with "--output" ("-o"), the "json" and "yaml" formats
+describe the same information in a machine-readable way, while "dot" (Graphviz)
+and "mermaid" render the dependency graph, where the edges point from a chart to
+the charts it depends on. For instance:
+
+  $ tssc topology --output=json
+  $ tssc topology --output=dot | dot -Tsvg > topology.svg
+
+To find out why a chart is part of the topology, use "--explain" with the chart
+name. It prints the chains of "depends-on" relationships leading from the enabled
+products to the chart, and the product toggles that would remove it:
+
+  $ tssc topology --explain=tssc-iam
+
+The "--lint" flag inspects the Helm charts and a local configuration file, the
+embedded configuration by default or "--config", entirely offline. It reports
+unknown charts on "depends-on", "product-name" missing from the configuration,
+"use-product-namespace" pointing at disabled products, products without a chart,
+and orphan charts. It exits non-zero when problems are found:
+
+  $ tssc topology --lint --config=config.yaml
 `
 
 // Cmd exposes the cobra instance.
@@ -53,6 +82,11 @@ func (t *Topology) Complete(_ []string) error {
 	if t.collection, err = resolver.NewCollection(charts); err != nil {
 		return err
 	}
+	// Linting is offline, the configuration is loaded from a local file.
+	if t.lint {
+		t.cfg, err = config.NewConfigFromFile(t.cfs, t.configPath)
+		return err
+	}
 	// Load the installer configuration from the cluster.
 	if t.cfg, err = bootstrapConfig(t.cmd.Context(), t.kube); err != nil {
 		return err
@@ -62,20 +96,63 @@ func (t *Topology) Complete(_ []string) error {
 
 // Validate validates the command.
 func (t *Topology) Validate() error {
+	if !slices.Contains(
+		resolver.OutputFormats,
+		resolver.OutputFormat(t.output),
+	) {
+		return fmt.Errorf("%w: %q, use one of: %v",
+			resolver.ErrUnsupportedOutputFormat,
+			t.output,
+			resolver.OutputFormats,
+		)
+	}
+	if t.explain != "" && t.output != string(resolver.TableOutput) {
+		return fmt.Errorf("--explain and --output can't be used together")
+	}
+	if t.lint && (t.explain != "" || t.output != string(resolver.TableOutput)) {
+		return fmt.Errorf("--lint can't be used with --explain or --output")
+	}
+	return nil
+}
+
+// runLint reports the problems found on the charts and configuration.
+func (t *Topology) runLint() error {
+	if err := t.cfg.Validate(); err != nil {
+		return err
+	}
+	problems := resolver.Lint(t.cfg, t.collection)
+	for _, p := range problems {
+		fmt.Println(p.String())
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("lint found %d problem(s)", len(problems))
+	}
+	fmt.Println("No problems found.")
 	return nil
 }
 
 // Run resolves the dependency graph.
 func (t *Topology) Run() error {
+	if t.lint {
+		return t.runLint()
+	}
 	// Resolving the dependency topology based on the installer configuration and
 	// Helm charts.
 	r := resolver.NewResolver(t.cfg, t.collection, resolver.NewTopology())
 	if err := r.Resolve(); err != nil {
 		return err
 	}
+	// Explaining why the informed chart is part of the topology.
+	if t.explain != "" {
+		e, err := r.Explain(t.explain)
+		if err != nil {
+			return err
+		}
+		e.Print(os.Stdout)
+		return nil
+	}
 	// Printing the resolved dependency to the standard output.
-	r.Print(os.Stdout)
-	return nil
+	return r.PrintFormat(os.Stdout, resolver.OutputFormat(t.output))
 }
 
 // NewTopology instantiates a new Topology subcommand.
@@ -91,9 +168,19 @@ func NewTopology(
 			Long:         topologyDesc,
 			SilenceUsage: true,
 		},
-		logger: logger.WithGroup("topology"),
-		cfs:    cfs,
-		kube:   kube,
+		logger:     logger.WithGroup("topology"),
+		cfs:        cfs,
+		kube:       kube,
+		output:     string(resolver.TableOutput),
+		configPath: config.DefaultRelativeConfigPath,
 	}
+	t.cmd.PersistentFlags().StringVarP(&t.output, "output", "o", t.output,
+		fmt.Sprintf("Output format, one of: %v", resolver.OutputFormats))
+	t.cmd.PersistentFlags().StringVar(&t.explain, "explain", t.explain,
+		"Explain why the informed chart is part of the topology")
+	t.cmd.PersistentFlags().BoolVar(&t.lint, "lint", t.lint,
+		"Lint the charts and configuration file, offline")
+	t.cmd.PersistentFlags().StringVar(&t.configPath, "config", t.configPath,
+		"Local configuration file, used with --lint")
 	return t
 }
@@ -0,0 +1,187 @@
+package subcmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/chartfs"
+	"github.com/redhat-appstudio/tssc-cli/pkg/checkpoint"
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/flags"
+	"github.com/redhat-appstudio/tssc-cli/pkg/installer"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+	"github.com/redhat-appstudio/tssc-cli/pkg/printer"
+	"github.com/redhat-appstudio/tssc-cli/pkg/resolver"
+
+	"github.com/spf13/cobra"
+)
+
+// Uninstall is the uninstall subcommand, removes the Helm releases deployed by
+// the installer in reverse topology order.
+type Uninstall struct {
+	cmd    *cobra.Command   // cobra command
+	logger *slog.Logger     // application logger
+	flags  *flags.Flags     // global flags
+	cfg    *config.Config   // installer configuration
+	cfs    *chartfs.ChartFS // embedded filesystem
+	kube   *k8s.Kube        // kubernetes client
+
+	collection *resolver.Collection // chart collection
+	product    string               // single product to uninstall
+}
+
+var _ Interface = &Uninstall{}
+
+const uninstallDesc = `
+Uninstalls the TSSC platform components.
+
+It should only be used to for experimental deployments. Production
+deployments are not supported.
+
+The dependency topology is resolved from the cluster configuration, and each Helm
+chart release is uninstalled in the reverse order, a chart is only removed after
+all the charts depending on it. Releases not found in the cluster are skipped.
+
+The "pre-delete.sh" and "post-delete.sh" scripts in the chart "hooks/" directory
+are executed before and after the release removal, respectively, using the same
+values of the release.
+
+A single product can be uninstalled with "--product", it removes the product
+charts and the dependencies no other enabled product requires. Disable the
+product in the cluster configuration afterwards, otherwise "tssc deploy" installs
+it again. E.g.:
+	tssc uninstall --product="Developer Hub"
+
+Use "--dry-run" to show the releases to be uninstalled, without changes.
+`
+
+// Cmd exposes the cobra instance.
+func (u *Uninstall) Cmd() *cobra.Command {
+	return u.cmd
+}
+
+// log logger with contextual information.
+func (u *Uninstall) log() *slog.Logger {
+	return u.flags.LoggerWith(u.logger.With("product", u.product))
+}
+
+// Complete loads the charts and the cluster configuration.
+func (u *Uninstall) Complete(_ []string) error {
+	// Load all charts from the embedded filesystem, or from a local directory.
+	charts, err := u.cfs.GetAllCharts()
+	if err != nil {
+		return err
+	}
+	// Create a new chart collection from the loaded charts.
+	if u.collection, err = resolver.NewCollection(charts); err != nil {
+		return err
+	}
+	// Load the installer configuration from the cluster.
+	if u.cfg, err = bootstrapConfig(u.cmd.Context(), u.kube); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate asserts the informed product is enabled in the configuration.
+func (u *Uninstall) Validate() error {
+	if u.product == "" {
+		return nil
+	}
+	spec, err := u.cfg.GetProduct(u.product)
+	if err != nil {
+		return err
+	}
+	if !spec.Enabled {
+		return fmt.Errorf("product %q is not enabled", u.product)
+	}
+	return nil
+}
+
+// dependencies returns the dependencies to uninstall, in reverse topology order.
+func (u *Uninstall) dependencies() (resolver.Dependencies, error) {
+	topology := resolver.NewTopology()
+	r := resolver.NewResolver(u.cfg, u.collection, topology)
+	if err := r.Resolve(); err != nil {
+		return nil, err
+	}
+	deps := slices.Clone(topology.Dependencies())
+	slices.Reverse(deps)
+	if u.product == "" {
+		return deps, nil
+	}
+
+	// Only the charts leaving the topology once the product is disabled are
+	// uninstalled, the remaining are still required by other products.
+	remaining, err := r.ResolveWithout(u.product)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(deps, func(d resolver.Dependency) bool {
+		return remaining.Contains(d.Name())
+	}), nil
+}
+
+// Run uninstalls the Helm releases in reverse topology order.
+func (u *Uninstall) Run() error {
+	printer.Disclaimer()
+
+	u.log().Debug("Resolving dependencies...")
+	deps, err := u.dependencies()
+	if err != nil {
+		return err
+	}
+
+	for index, dep := range deps {
+		fmt.Printf("\n\n%s\n", strings.Repeat("#", 60))
+		fmt.Printf("# [%d/%d] Uninstalling '%s' from '%s'.\n",
+			index+1, len(deps), dep.Name(), dep.Namespace())
+		fmt.Printf("%s\n", strings.Repeat("#", 60))
+
+		i := installer.NewInstaller(
+			u.log(), u.flags, u.kube, &dep, os.Stdout, os.Stderr)
+		if err = i.Uninstall(); err != nil {
+			return fmt.Errorf("%s: %w", dep.Name(), err)
+		}
+	}
+
+	// The deployment checkpoint is no longer meaningful once all releases are
+	// removed.
+	if u.product == "" && !u.flags.DryRun {
+		u.log().Debug("Removing the deployment checkpoint")
+		if err = checkpoint.NewManager(u.kube, u.cfg.Installer.Namespace).
+			Delete(u.cmd.Context()); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Uninstall complete!\n")
+	return nil
+}
+
+// NewUninstall instantiates the uninstall subcommand.
+func NewUninstall(
+	logger *slog.Logger,
+	f *flags.Flags,
+	cfs *chartfs.ChartFS,
+	kube *k8s.Kube,
+) Interface {
+	u := &Uninstall{
+		cmd: &cobra.Command{
+			Use:          "uninstall",
+			Short:        "Remove TSSC platform components",
+			Long:         uninstallDesc,
+			SilenceUsage: true,
+		},
+		logger: logger.WithGroup("uninstall"),
+		flags:  f,
+		cfs:    cfs,
+		kube:   kube,
+	}
+	u.cmd.PersistentFlags().StringVar(&u.product, "product", u.product,
+		"Uninstall a single product, and the dependencies no longer required")
+	return u
+}
@@ -0,0 +1,165 @@
+package trust
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+	"github.com/redhat-appstudio/tssc-cli/pkg/k8s"
+)
+
+// CABundleSetting the installer setting carrying additional trusted CAs.
+const CABundleSetting = "caBundle"
+
+// ErrInvalidCABundle the CA bundle doesn't carry valid PEM certificates.
+var ErrInvalidCABundle = errors.New("invalid CA bundle")
+
+// Trust the settings shared by the installer outbound connections, and by the
+// services deployed, the cluster-wide proxy and the trusted certificates.
+type Trust struct {
+	// HTTPProxy the proxy URL for HTTP requests.
+	HTTPProxy string
+	// HTTPSProxy the proxy URL for HTTPS requests.
+	HTTPSProxy string
+	// NoProxy comma-separated hosts, domains and CIDRs not proxied.
+	NoProxy string
+	// ClusterCABundle the PEM certificates trusted by the cluster-wide proxy.
+	ClusterCABundle string
+	// CABundle the PEM certificates supplied by the user.
+	CABundle string
+}
+
+// Bundle returns the cluster and the user supplied certificates, PEM encoded.
+func (t *Trust) Bundle() string {
+	bundles := []string{}
+	for _, b := range []string{t.ClusterCABundle, t.CABundle} {
+		if b = strings.TrimSpace(b); b != "" {
+			bundles = append(bundles, b)
+		}
+	}
+	if len(bundles) == 0 {
+		return ""
+	}
+	return strings.Join(bundles, "\n") + "\n"
+}
+
+// CertPool returns the system certificates pool, with the trusted bundle added.
+func (t *Trust) CertPool() (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	bundle := t.Bundle()
+	if bundle != "" && !pool.AppendCertsFromPEM([]byte(bundle)) {
+		return nil, fmt.Errorf("%w: no PEM certificates found", ErrInvalidCABundle)
+	}
+	return pool, nil
+}
+
+// Proxy returns the proxy function for the HTTP transport. Without the cluster
+// proxy settings, the proxy environment variables are used instead.
+func (t *Trust) Proxy() func(*http.Request) (*url.URL, error) {
+	if t.HTTPProxy == "" && t.HTTPSProxy == "" {
+		return http.ProxyFromEnvironment
+	}
+	return func(r *http.Request) (*url.URL, error) {
+		proxy := t.HTTPProxy
+		if r.URL.Scheme == "https" {
+			proxy = t.HTTPSProxy
+		}
+		if proxy == "" || noProxy(r.URL.Hostname(), t.NoProxy) {
+			return nil, nil
+		}
+		return url.Parse(proxy)
+	}
+}
+
+// HTTPClient returns a HTTP client using the proxy and trusting the bundle.
+func (t *Trust) HTTPClient() (*http.Client, error) {
+	pool, err := t.CertPool()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = t.Proxy()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// LoadCABundle returns the user supplied CA bundle, the "caBundle" setting and
+// the informed PEM file, when not empty. The certificates are validated.
+func LoadCABundle(settings config.Settings, path string) (string, error) {
+	bundles := []string{}
+	if setting, ok := settings[CABundleSetting].(string); ok && setting != "" {
+		bundles = append(bundles, strings.TrimSpace(setting))
+	}
+	if path != "" {
+		payload, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidCABundle, err)
+		}
+		bundles = append(bundles, strings.TrimSpace(string(payload)))
+	}
+	if len(bundles) == 0 {
+		return "", nil
+	}
+	bundle := strings.Join(bundles, "\n") + "\n"
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(bundle)) {
+		return "", fmt.Errorf("%w: no PEM certificates found", ErrInvalidCABundle)
+	}
+	return bundle, nil
+}
+
+// NewTrust collects the cluster-wide proxy settings and trusted certificates,
+// together with the CA bundle supplied by the user. When the cluster doesn't
+// serve the proxy settings, or the user isn't allowed to read them, the proxy
+// is not employed.
+func NewTrust(
+	ctx context.Context,
+	logger *slog.Logger,
+	kube *k8s.Kube,
+	cfg *config.Spec,
+	caBundlePath string,
+) (*Trust, error) {
+	caBundle, err := LoadCABundle(cfg.Settings, caBundlePath)
+	if err != nil {
+		return nil, err
+	}
+	t := &Trust{CABundle: caBundle}
+
+	proxy, err := k8s.GetOpenShiftProxy(ctx, kube)
+	if err != nil {
+		if !k8s.IsUnavailable(err) {
+			return nil, err
+		}
+		logger.Debug("Cluster-wide proxy unavailable, not using a proxy",
+			"err", err)
+		return t, nil
+	}
+	if proxy == nil {
+		return t, nil
+	}
+	t.HTTPProxy = proxy.Status.HTTPProxy
+	t.HTTPSProxy = proxy.Status.HTTPSProxy
+	t.NoProxy = proxy.Status.NoProxy
+
+	t.ClusterCABundle, err = k8s.GetOpenShiftTrustedCA(ctx, kube, proxy)
+	if err != nil {
+		if !k8s.IsUnavailable(err) {
+			return nil, err
+		}
+		logger.Debug("Cluster trusted CA bundle unavailable", "err", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,113 @@
+package trust
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-appstudio/tssc-cli/pkg/config"
+
+	o "github.com/onsi/gomega"
+)
+
+// serverCABundle returns the test server certificate, PEM encoded.
+func serverCABundle(s *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.Certificate().Raw,
+	}))
+}
+
+func TestLoadCABundle(t *testing.T) {
+	s := httptest.NewTLSServer(http.NotFoundHandler())
+	defer s.Close()
+	caBundle := serverCABundle(s)
+
+	t.Run("empty", func(t *testing.T) {
+		g := o.NewWithT(t)
+		bundle, err := LoadCABundle(config.Settings{}, "")
+		g.Expect(err).To(o.Succeed())
+		g.Expect(bundle).To(o.BeEmpty())
+	})
+
+	t.Run("setting and file", func(t *testing.T) {
+		g := o.NewWithT(t)
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		g.Expect(os.WriteFile(path, []byte(caBundle), 0o600)).To(o.Succeed())
+
+		bundle, err := LoadCABundle(
+			config.Settings{CABundleSetting: caBundle}, path)
+		g.Expect(err).To(o.Succeed())
+		g.Expect(bundle).To(o.Equal(caBundle + caBundle))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		g := o.NewWithT(t)
+		_, err := LoadCABundle(
+			config.Settings{CABundleSetting: "not a certificate"}, "")
+		g.Expect(err).To(o.MatchError(ErrInvalidCABundle))
+
+		_, err = LoadCABundle(config.Settings{}, "/does/not/exist.pem")
+		g.Expect(err).To(o.MatchError(ErrInvalidCABundle))
+	})
+}
+
+func TestTrust_HTTPClient(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	))
+	defer s.Close()
+
+	t.Run("untrusted", func(t *testing.T) {
+		g := o.NewWithT(t)
+		c, err := (&Trust{}).HTTPClient()
+		g.Expect(err).To(o.Succeed())
+		_, err = c.Get(s.URL)
+		g.Expect(err).To(o.HaveOccurred())
+	})
+
+	t.Run("trusted", func(t *testing.T) {
+		g := o.NewWithT(t)
+		c, err := (&Trust{CABundle: serverCABundle(s)}).HTTPClient()
+		g.Expect(err).To(o.Succeed())
+		res, err := c.Get(s.URL)
+		g.Expect(err).To(o.Succeed())
+		defer res.Body.Close()
+		g.Expect(res.StatusCode).To(o.Equal(http.StatusNoContent))
+	})
+}
+
+func TestTrust_Proxy(t *testing.T) {
+	g := o.NewWithT(t)
+
+	tr := &Trust{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://secure-proxy.example.com:3128",
+		NoProxy:    ".cluster.local,10.0.0.0/16,internal.example.com",
+	}
+	proxyFor := func(rawURL string) string {
+		u, err := url.Parse(rawURL)
+		g.Expect(err).To(o.Succeed())
+		proxy, err := tr.Proxy()(&http.Request{URL: u})
+		g.Expect(err).To(o.Succeed())
+		if proxy == nil {
+			return ""
+		}
+		return proxy.Host
+	}
+
+	g.Expect(proxyFor("http://github.com")).To(o.Equal("proxy.example.com:3128"))
+	g.Expect(proxyFor("https://github.com")).
+		To(o.Equal("secure-proxy.example.com:3128"))
+	g.Expect(proxyFor("https://svc.ns.svc.cluster.local")).To(o.BeEmpty())
+	g.Expect(proxyFor("https://10.0.1.2:8443")).To(o.BeEmpty())
+	g.Expect(proxyFor("https://gitlab.internal.example.com")).To(o.BeEmpty())
+	g.Expect(proxyFor("https://internal.example.com.evil.io")).
+		To(o.Equal("secure-proxy.example.com:3128"))
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+echo "This script runs after the removal of the chart"
@@ -0,0 +1,4 @@
+#!/usr/bin/env bash
+
+echo "This script runs before the removal of the chart"
+echo "# INSTALLER__KEY__NESTED='${INSTALLER__KEY__NESTED}'"